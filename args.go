@@ -0,0 +1,134 @@
+package configuration
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+const (
+	definePrefix = "-D"
+	setFlag      = "--set"
+)
+
+// ParseArgs extracts -Dpath=value and --set path=value overrides from the command
+// line arguments. It returns the overrides as a config, meant to take precedence via
+// WithFallback, together with the arguments it did not consume. Everything after a
+// "--" argument is left untouched.
+func ParseArgs(args []string) (*Config, []string, error) {
+	root := hocon.NewHoconObject()
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		var assignment string
+		switch {
+		case arg == "--":
+			rest = append(rest, args[i:]...)
+			i = len(args)
+			continue
+		case strings.HasPrefix(arg, definePrefix):
+			assignment = strings.TrimPrefix(arg, definePrefix)
+		case strings.HasPrefix(arg, setFlag+"="):
+			assignment = strings.TrimPrefix(arg, setFlag+"=")
+		case arg == setFlag:
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("missing path=value after %s", setFlag)
+			}
+			i++
+			assignment = args[i]
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+
+		idx := strings.IndexByte(assignment, '=')
+		if idx <= 0 {
+			return nil, nil, fmt.Errorf("expected path=value, got %q", assignment)
+		}
+
+		keys, err := hocon.ParsePath(assignment[:idx])
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(keys) == 0 {
+			return nil, nil, fmt.Errorf("empty path in %q", assignment)
+		}
+		setPathString(root, keys, assignment[idx+1:], hocon.NewHoconOrigin("command line", 0))
+	}
+
+	config, err := newConfigFromObject(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, rest, nil
+}
+
+// FlagOverlay keeps the flags registered by BindFlags.
+type FlagOverlay struct {
+	flagSet *flag.FlagSet
+	values  map[string]*string
+	keys    map[string]hocon.Path
+}
+
+// BindFlags registers a string flag on the flag set for every scalar leaf of the
+// reference config, named after the leaf path and defaulting to the leaf value.
+// The usage of a flag is the comment documenting the leaf, if any. Arrays are not
+// bound.
+func BindFlags(flagSet *flag.FlagSet, reference *Config) *FlagOverlay {
+	overlay := &FlagOverlay{
+		flagSet: flagSet,
+		values:  map[string]*string{},
+		keys:    map[string]hocon.Path{},
+	}
+
+	if reference.IsEmpty() {
+		return overlay
+	}
+
+	for _, entry := range reference.Entries() {
+		if !entry.Value.IsString() {
+			continue
+		}
+
+		name := entry.Path.Render()
+		if flagSet.Lookup(name) != nil {
+			continue
+		}
+
+		defaultValue, err := entry.Value.GetString()
+		if err != nil {
+			continue
+		}
+
+		usage := strings.Join(entry.Value.Comments(), " ")
+		if len(usage) == 0 {
+			usage = fmt.Sprintf("overrides %s", name)
+		}
+
+		overlay.values[name] = flagSet.String(name, defaultValue, usage)
+		overlay.keys[name] = entry.Path
+	}
+
+	return overlay
+}
+
+// Config returns the values of the flags that were set on the command line.
+// It must be called after the flag set is parsed.
+func (p *FlagOverlay) Config() (*Config, error) {
+	root := hocon.NewHoconObject()
+
+	p.flagSet.Visit(func(f *flag.Flag) {
+		value, exist := p.values[f.Name]
+		if !exist {
+			return
+		}
+		setPathString(root, p.keys[f.Name], *value, hocon.NewHoconOrigin("command line flag -"+f.Name, 0))
+	})
+
+	return newConfigFromObject(root)
+}
@@ -0,0 +1,128 @@
+package configuration
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArgs(t *testing.T) {
+	overrides, rest, err := ParseArgs([]string{
+		"-Dakka.loglevel=DEBUG",
+		"serve",
+		"--set", "akka.actor.timeout=5s",
+		"--set=server.port=8080",
+		"--", "-Dnot.parsed=1",
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"serve", "--", "-Dnot.parsed=1"}, rest)
+
+	app, err := ParseString(`akka { loglevel = INFO, version = "1.0" }`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conf, err := overrides.WithFallback(app)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	loglevel, err := conf.GetString("akka.loglevel")
+	assert.Nil(t, err)
+	assert.Equal(t, "DEBUG", loglevel)
+
+	version, err := conf.GetString("akka.version")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0", version)
+
+	port, err := conf.GetInt32("server.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8080), port)
+
+	assert.False(t, conf.HasPath("not"))
+}
+
+func TestParseArgs_KeepsStrings(t *testing.T) {
+	overrides, _, err := ParseArgs([]string{"-Dapp.version=1.10", "--set", "app.ratio=1e5"})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, `{app={version="1.10",ratio="1e5"}}`, overrides.Render())
+
+	ratio, err := overrides.GetFloat64("app.ratio")
+	assert.Nil(t, err)
+	assert.Equal(t, 1e5, ratio)
+}
+
+func TestParseArgs_Errors(t *testing.T) {
+	_, _, err := ParseArgs([]string{"--set"})
+	assert.NotNil(t, err)
+
+	_, _, err = ParseArgs([]string{"-Dakka.loglevel"})
+	assert.NotNil(t, err)
+
+	_, _, err = ParseArgs([]string{"-D=value"})
+	assert.NotNil(t, err)
+}
+
+func TestBindFlags(t *testing.T) {
+	reference, err := ParseString(`akka { loglevel = INFO, loggers = [a, b], actor { timeout = 1s } }`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	overlay := BindFlags(flagSet, reference)
+
+	assert.NotNil(t, flagSet.Lookup("akka.loglevel"))
+	assert.Equal(t, "1s", flagSet.Lookup("akka.actor.timeout").DefValue)
+	assert.Nil(t, flagSet.Lookup("akka.loggers"))
+
+	if !assert.Nil(t, flagSet.Parse([]string{"-akka.loglevel", "DEBUG"})) {
+		return
+	}
+
+	overrides, err := overlay.Config()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, overrides.HasPath("akka.actor.timeout"))
+
+	conf, err := overrides.WithFallback(reference)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	loglevel, err := conf.GetString("akka.loglevel")
+	assert.Nil(t, err)
+	assert.Equal(t, "DEBUG", loglevel)
+
+	timeout, err := conf.GetString("akka.actor.timeout")
+	assert.Nil(t, err)
+	assert.Equal(t, "1s", timeout)
+}
+
+func TestBindFlags_UsageFromComments(t *testing.T) {
+	reference, err := ParseString(`
+akka {
+  # Log level used by the configured loggers
+  # Options: OFF, ERROR, WARNING, INFO, DEBUG
+  loglevel = INFO
+  version = 1
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	BindFlags(flagSet, reference)
+
+	assert.Equal(t, "Log level used by the configured loggers Options: OFF, ERROR, WARNING, INFO, DEBUG",
+		flagSet.Lookup("akka.loglevel").Usage)
+	assert.Equal(t, "overrides akka.version", flagSet.Lookup("akka.version").Usage)
+}
@@ -0,0 +1,231 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+const defaultBuilderOrigin = "builder"
+
+// Builder builds a Config programmatically, without going through the parser. The
+// first error met is kept and returned by Build, so calls can be chained.
+type Builder struct {
+	root   *hocon.HoconValue
+	origin *hocon.HoconOrigin
+	err    error
+}
+
+// NewBuilder creates an empty builder. Every value it sets gets a synthetic origin
+// with the given description, "builder" by default.
+func NewBuilder(origin ...string) *Builder {
+	description := defaultBuilderOrigin
+	if len(origin) > 0 {
+		description = origin[0]
+	}
+
+	b := &Builder{origin: hocon.NewHoconOrigin(description, 0)}
+	b.root = b.newValue(hocon.NewHoconObject())
+	return b
+}
+
+// Set stores the value at the path. Strings are stored as quoted strings, numbers
+// and booleans keep their type and nil is stored as null. Durations, slices, maps
+// with string keys, *Config and *hocon.HoconValue are accepted as well.
+func (b *Builder) Set(path string, value interface{}) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	hoconValue, err := b.toValue(value)
+	if err != nil {
+		b.err = fmt.Errorf("cannot set %s: %s", path, err)
+		return b
+	}
+
+	return b.setValue(path, hoconValue)
+}
+
+// SetDuration stores the duration at the path, in the largest unit holding it
+// exactly, e.g. 3s or 1500ms.
+func (b *Builder) SetDuration(path string, duration time.Duration) *Builder {
+	return b.Set(path, duration)
+}
+
+// SetList stores the values at the path as an array.
+func (b *Builder) SetList(path string, values ...interface{}) *Builder {
+	return b.Set(path, values)
+}
+
+// Object builds the object at the path with the callback, starting from the object
+// already stored there, if any.
+func (b *Builder) Object(path string, build func(b *Builder)) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	nested := &Builder{origin: b.origin}
+	nested.root = nested.newValue(hocon.NewHoconObject())
+
+	if parsedPath, err := hocon.ParsePath(path); err == nil {
+		if current, err := b.root.GetNode(parsedPath); err == nil && current.IsObject() {
+			nested.root = current
+		}
+	}
+
+	build(nested)
+	if nested.err != nil {
+		b.err = nested.err
+		return b
+	}
+
+	return b.setValue(path, nested.root)
+}
+
+// Build returns the config built so far, the builder may still be used afterwards.
+func (b *Builder) Build() (*Config, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return &Config{root: b.root}, nil
+}
+
+func (b *Builder) setValue(path string, value *hocon.HoconValue) *Builder {
+	parsedPath, err := hocon.ParsePath(path)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	if len(parsedPath) == 0 {
+		b.err = errors.New("cannot set the root of a Config")
+		return b
+	}
+
+	root, err := b.root.WithPathValue(parsedPath, value)
+	if err != nil {
+		b.err = fmt.Errorf("cannot set %s: %s", path, err)
+		return b
+	}
+
+	b.root = root
+	return b
+}
+
+func (b *Builder) newValue(element hocon.HoconElement) *hocon.HoconValue {
+	value := hocon.NewHoconValue()
+	value.AppendValue(element)
+	value.SetOrigin(b.origin)
+	return value
+}
+
+func (b *Builder) toValue(value interface{}) (*hocon.HoconValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return b.newValue(hocon.NewHoconLiteral("null")), nil
+	case string:
+		return b.newValue(hocon.NewHoconQuotedLiteral(v)), nil
+	case bool:
+		return b.newValue(hocon.NewHoconLiteral(strconv.FormatBool(v))), nil
+	case time.Duration:
+		if v < 0 {
+			return nil, fmt.Errorf("negative duration %s", v)
+		}
+		return b.newValue(hocon.NewHoconLiteral(durationText(v))), nil
+	case *hocon.HoconValue:
+		if v == nil {
+			return nil, errors.New("nil HoconValue")
+		}
+		return v, nil
+	case *Config:
+		if v.IsEmpty() {
+			return b.newValue(hocon.NewHoconObject()), nil
+		}
+		return v.root, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return b.newValue(hocon.NewHoconLiteral(strconv.FormatInt(rv.Int(), 10))), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return b.newValue(hocon.NewHoconLiteral(strconv.FormatUint(rv.Uint(), 10))), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("unsupported number %v", f)
+		}
+		bitSize := 64
+		if rv.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		return b.newValue(hocon.NewHoconLiteral(strconv.FormatFloat(f, 'g', -1, bitSize))), nil
+	case reflect.Slice, reflect.Array:
+		arr := hocon.NewHoconArray()
+		for i := 0; i < rv.Len(); i++ {
+			element, err := b.toValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			arr.Append(element)
+		}
+		return b.newValue(arr), nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", rv.Type().Key())
+		}
+
+		keys := make([]string, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+
+		nested := &Builder{origin: b.origin}
+		nested.root = nested.newValue(hocon.NewHoconObject())
+		for _, key := range keys {
+			element, err := b.toValue(rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			root, err := nested.root.WithPathValue(hocon.PathOf(key), element)
+			if err != nil {
+				return nil, err
+			}
+			nested.root = root
+		}
+		return nested.root, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
+
+// durationText renders a positive duration in the largest unit holding it exactly.
+func durationText(duration time.Duration) string {
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "micros"},
+	}
+
+	for _, unit := range units {
+		if duration%unit.size == 0 {
+			return strconv.FormatInt(int64(duration/unit.size), 10) + unit.name
+		}
+	}
+	return strconv.FormatInt(int64(duration), 10) + "ns"
+}
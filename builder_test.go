@@ -0,0 +1,153 @@
+package configuration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	conf, err := NewBuilder().
+		Set("a.b", 5).
+		Set("a.ratio", 0.25).
+		Set("a.enabled", true).
+		Set("a.name", "with \"quotes\" and spaces").
+		Set("a.flag", "true").
+		Set("a.none", nil).
+		SetDuration("t", 3*time.Second).
+		SetDuration("precise", 1500*time.Millisecond).
+		SetList("l", 1, 2, 3).
+		Set("names", []string{"x", "y"}).
+		Set("labels", map[string]string{"env": "prod"}).
+		Object("nested", func(b *Builder) {
+			b.Set("host", "localhost").Set("port", 8080)
+		}).
+		Object("nested", func(b *Builder) {
+			b.Set("tls", false)
+		}).
+		Build()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, err := conf.GetInt64("a.b"); assert.Nil(t, err) {
+		assert.Equal(t, int64(5), value)
+	}
+	if value, err := conf.GetFloat64("a.ratio"); assert.Nil(t, err) {
+		assert.Equal(t, 0.25, value)
+	}
+	if value, err := conf.GetBoolean("a.enabled"); assert.Nil(t, err) {
+		assert.True(t, value)
+	}
+	if value, err := conf.GetString("a.name"); assert.Nil(t, err) {
+		assert.Equal(t, "with \"quotes\" and spaces", value)
+	}
+	if value, err := conf.GetString("a.none"); assert.Nil(t, err) {
+		assert.Equal(t, "", value)
+	}
+	if value, err := conf.GetTimeDuration("t"); assert.Nil(t, err) {
+		assert.Equal(t, 3*time.Second, value)
+	}
+	if value, err := conf.GetTimeDuration("precise"); assert.Nil(t, err) {
+		assert.Equal(t, 1500*time.Millisecond, value)
+	}
+	if value, err := conf.GetInt64List("l"); assert.Nil(t, err) {
+		assert.Equal(t, []int64{1, 2, 3}, value)
+	}
+	if value, err := conf.GetStringList("names"); assert.Nil(t, err) {
+		assert.Equal(t, []string{"x", "y"}, value)
+	}
+	if value, err := conf.GetString("labels.env"); assert.Nil(t, err) {
+		assert.Equal(t, "prod", value)
+	}
+	if value, err := conf.GetString("nested.host"); assert.Nil(t, err) {
+		assert.Equal(t, "localhost", value)
+	}
+	if value, err := conf.GetInt32("nested.port"); assert.Nil(t, err) {
+		assert.Equal(t, int32(8080), value)
+	}
+	if value, err := conf.GetBoolean("nested.tls", true); assert.Nil(t, err) {
+		assert.False(t, value)
+	}
+
+	node, err := conf.GetNode("a.b")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "builder", node.Origin().String())
+	}
+}
+
+func TestBuilder_Origin(t *testing.T) {
+	conf, err := NewBuilder("defaults").Set("a", 1).Build()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	node, err := conf.GetNode("a")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "defaults", node.Origin().Description())
+	}
+}
+
+func TestBuilder_BuildIsNotAffectedByLaterSets(t *testing.T) {
+	b := NewBuilder().Set("a", 1)
+	first, err := b.Build()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	second, err := b.Set("a", 2).Build()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, err := first.GetInt32("a"); assert.Nil(t, err) {
+		assert.Equal(t, int32(1), value)
+	}
+	if value, err := second.GetInt32("a"); assert.Nil(t, err) {
+		assert.Equal(t, int32(2), value)
+	}
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(b *Builder) *Builder
+	}{
+		{
+			name:  "invalid path",
+			build: func(b *Builder) *Builder { return b.Set("a..b", 1) },
+		},
+		{
+			name:  "root path",
+			build: func(b *Builder) *Builder { return b.Set("", 1) },
+		},
+		{
+			name:  "unsupported type",
+			build: func(b *Builder) *Builder { return b.Set("a", struct{}{}) },
+		},
+		{
+			name:  "not a number",
+			build: func(b *Builder) *Builder { return b.Set("a", math.NaN()) },
+		},
+		{
+			name:  "negative duration",
+			build: func(b *Builder) *Builder { return b.SetDuration("a", -time.Second) },
+		},
+		{
+			name: "error in nested object",
+			build: func(b *Builder) *Builder {
+				return b.Object("a", func(nested *Builder) { nested.Set("b", map[int]int{1: 1}) })
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := tt.build(NewBuilder()).Set("z", 1).Build()
+			assert.NotNil(t, err)
+			assert.Nil(t, conf)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package configuration
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	concurrencyTestConfig = `
+app {
+  name = demo
+  http { port = 8080 }
+}
+`
+	concurrencyTestFallback = `
+app {
+  name = fallback
+  http { port = 80, host = localhost }
+  db { url = "jdbc:h2:mem" }
+}
+`
+)
+
+func TestConfig_WithFallbackDoesNotModifyOperands(t *testing.T) {
+	conf := mustParse(t, concurrencyTestConfig)
+	fallback := mustParse(t, concurrencyTestFallback)
+	confBefore, fallbackBefore := conf.String(), fallback.String()
+
+	merged, err := conf.WithFallback(fallback)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, confBefore, conf.String())
+	assert.Equal(t, fallbackBefore, fallback.String())
+	assert.False(t, conf.HasPath("app.http.host"))
+	assert.False(t, conf.HasPath("app.db"))
+
+	host, err := merged.GetString("app.http.host")
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", host)
+
+	port, err := merged.GetInt32("app.http.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8080), port)
+
+	http, err := merged.GetConfig("app.http")
+	if assert.Nil(t, err) {
+		_, err = http.WithFallback(mustParse(t, `extra = 1`))
+		assert.Nil(t, err)
+	}
+	assert.False(t, merged.HasPath("app.http.extra"))
+}
+
+func TestConfig_ConcurrentReadsAndMerges(t *testing.T) {
+	conf := mustParse(t, concurrencyTestConfig)
+	fallback := mustParse(t, concurrencyTestFallback)
+	confBefore, fallbackBefore := conf.String(), fallback.String()
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				merged, err := conf.WithFallback(fallback)
+				if !assert.Nil(t, err) {
+					return
+				}
+
+				extra := mustParse(t, fmt.Sprintf("app.http.worker = %d", i))
+				layered, err := extra.WithFallback(merged)
+				if !assert.Nil(t, err) {
+					return
+				}
+
+				worker, err := layered.GetInt32("app.http.worker")
+				assert.Nil(t, err)
+				assert.Equal(t, int32(i), worker)
+
+				http, err := merged.GetConfig("app.http")
+				if assert.Nil(t, err) {
+					port, err := http.Copy().GetInt32("port")
+					assert.Nil(t, err)
+					assert.Equal(t, int32(8080), port)
+				}
+
+				edited, err := merged.WithValue("app.name", literalValue(fmt.Sprint(i)))
+				if assert.Nil(t, err) {
+					name, err := edited.GetString("app.name")
+					assert.Nil(t, err)
+					assert.Equal(t, fmt.Sprint(i), name)
+				}
+
+				name, err := conf.GetString("app.name")
+				assert.Nil(t, err)
+				assert.Equal(t, "demo", name)
+				assert.Len(t, merged.Entries(), 4)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, confBefore, conf.String())
+	assert.Equal(t, fallbackBefore, fallback.String())
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"strings"
 	"time"
 
 	"github.com/goreflect/go_hocon/hocon"
@@ -14,6 +13,9 @@ type Config struct {
 	root          *hocon.HoconValue
 	substitutions []*hocon.HoconSubstitution
 	fallback      *Config
+	// unresolved is set on the layers parsed by this package whose substitutions
+	// are still to be resolved against a merged tree
+	unresolved bool
 }
 
 func NewConfigFromRoot(root *hocon.HoconRoot) (*Config, error) {
@@ -64,268 +66,220 @@ func (p *Config) Copy(fallback ...*Config) *Config {
 	}
 }
 
+// ErrMissingPath is wrapped by the errors returned when a config has no value at a
+// path, use errors.Is to tell it from the other errors.
+var ErrMissingPath = errors.New("missing path")
+
+func missingPathError(path hocon.Path) error {
+	return fmt.Errorf("%w: %s", ErrMissingPath, path.Render())
+}
+
+// GetNode returns the value at the path, with an error wrapping ErrMissingPath when
+// there is none.
 func (p *Config) GetNode(path string) (*hocon.HoconValue, error) {
+	parsedPath, err := hocon.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetNodeByPath(parsedPath)
+}
+
+// GetNodeByPath returns the value addressed by the path, looking it up in the
+// fallback config when it cannot be found. The error wraps ErrMissingPath when
+// neither holds it, or when the path goes through a value that has no such key.
+func (p *Config) GetNodeByPath(path hocon.Path) (*hocon.HoconValue, error) {
 	if p == nil {
-		return nil, fmt.Errorf("cannot get node from nil Config")
+		return nil, missingPathError(path)
 	}
 
-	elements := splitDottedPathHonouringQuotes(path)
 	currentNode := p.root
 
-	if currentNode == nil {
-		return nil, errors.New("current node should not be null")
-	}
+	for i, key := range path {
+		if currentNode.IsEmpty() {
+			currentNode = nil
+			break
+		}
 
-	for _, key := range elements {
-		var err error
-		currentNode, err = currentNode.GetChildObject(key)
+		child, err := currentNode.GetChild(key)
 		if err != nil {
-			return nil, err
-		}
+			// the value overrides whatever the fallback holds below it
+			if currentNode.IsArray() {
+				return nil, fmt.Errorf("%w: %s: %v", ErrMissingPath, path.Render(), err)
+			}
 
-		if currentNode == nil {
-			if p.fallback != nil {
-				return p.fallback.GetNode(path)
+			name := "the root"
+			if i > 0 {
+				name = path[:i].Render()
 			}
-			return nil, fmt.Errorf("cannot get node from nil Config")
+			return nil, fmt.Errorf("%w: %s: %s is of type %s, not an object", ErrMissingPath, path.Render(), name, docType(currentNode))
 		}
+		currentNode = child
 	}
-	return currentNode, nil
-}
 
-func (p *Config) GetBoolean(path string, defaultVal ...bool) (bool, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return false, err
-	}
-
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
+	if currentNode == nil {
+		if p.fallback != nil {
+			return p.fallback.GetNodeByPath(path)
 		}
-		return false, nil
+		return nil, missingPathError(path)
 	}
-	return obj.GetBoolean()
+	return currentNode, nil
 }
 
-func (p *Config) GetByteSize(path string) (*big.Int, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return nil, err
-	}
+// The getters below return an error wrapping ErrMissingPath when the path is missing,
+// including a path going through a scalar or through an array with a key that is not
+// an index, unless a default value is given. The Lookup variants tell whether the
+// path is set instead, and the Must variants panic on any error.
 
-	if obj == nil {
-		return big.NewInt(-1), nil
-	}
-	return obj.GetByteSize()
+func (p *Config) GetBoolean(path string, defaultVal ...bool) (bool, error) {
+	return getValue(p, path, (*hocon.HoconValue).GetBoolean, defaultVal)
 }
 
-func (p *Config) GetInt32(path string, defaultVal ...int32) (int32, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return 0, err
-	}
+func (p *Config) GetByteSize(path string, defaultVal ...*big.Int) (*big.Int, error) {
+	return getValue(p, path, (*hocon.HoconValue).GetByteSize, defaultVal)
+}
 
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
-		}
-		return 0, nil
-	}
-	return obj.GetInt32()
+func (p *Config) GetInt32(path string, defaultVal ...int32) (int32, error) {
+	return getValue(p, path, (*hocon.HoconValue).GetInt32, defaultVal)
 }
 
 func (p *Config) GetInt64(path string, defaultVal ...int64) (int64, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return 0, err
-	}
-
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
-		}
-		return 0, nil
-	}
-	return obj.GetInt64()
+	return getValue(p, path, (*hocon.HoconValue).GetInt64, defaultVal)
 }
 
 func (p *Config) GetString(path string, defaultVal ...string) (string, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return "", err
-	}
-
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
-		}
-		return "", nil
-	}
-	return obj.GetString()
+	return getValue(p, path, (*hocon.HoconValue).GetString, defaultVal)
 }
 
 func (p *Config) GetFloat32(path string, defaultVal ...float32) (float32, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return 0, err
-	}
-
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
-		}
-		return 0, nil
-	}
-	return obj.GetFloat32()
+	return getValue(p, path, (*hocon.HoconValue).GetFloat32, defaultVal)
 }
 
 func (p *Config) GetFloat64(path string, defaultVal ...float64) (float64, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return 0, err
-	}
-
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
-		}
-		return 0, nil
-	}
-	return obj.GetFloat64()
+	return getValue(p, path, (*hocon.HoconValue).GetFloat64, defaultVal)
 }
 
 func (p *Config) GetTimeDuration(path string, defaultVal ...time.Duration) (time.Duration, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return 0, err
-	}
-
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
-		}
-		return 0, nil
-	}
-	return obj.GetTimeDuration(true)
+	return getValue(p, path, durationInfiniteAllowed, defaultVal)
 }
 
 func (p *Config) GetTimeDurationInfiniteNotAllowed(path string, defaultVal ...time.Duration) (time.Duration, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return 0, err
-	}
-
-	if obj == nil {
-		if len(defaultVal) > 0 {
-			return defaultVal[0], nil
-		}
-		return 0, nil
-	}
-	return obj.GetTimeDuration(false)
+	return getValue(p, path, durationInfiniteNotAllowed, defaultVal)
 }
 
 func (p *Config) GetBooleanList(path string) ([]bool, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return nil, err
-	}
-
-	return obj.GetBooleanList()
+	return getValue(p, path, (*hocon.HoconValue).GetBooleanList, nil)
 }
 
 func (p *Config) GetFloat32List(path string) ([]float32, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return nil, err
-	}
-
-	return obj.GetFloat32List()
+	return getValue(p, path, (*hocon.HoconValue).GetFloat32List, nil)
 }
 
 func (p *Config) GetFloat64List(path string) ([]float64, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return nil, err
-	}
-
-	return obj.GetFloat64List()
+	return getValue(p, path, (*hocon.HoconValue).GetFloat64List, nil)
 }
 
 func (p *Config) GetInt32List(path string) ([]int32, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return nil, err
-	}
-
-	return obj.GetInt32List()
+	return getValue(p, path, (*hocon.HoconValue).GetInt32List, nil)
 }
 
 func (p *Config) GetInt64List(path string) ([]int64, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return nil, err
-	}
-
-	return obj.GetInt64List()
+	return getValue(p, path, (*hocon.HoconValue).GetInt64List, nil)
 }
 
 func (p *Config) GetByteList(path string) ([]byte, error) {
-	obj, err := p.GetNode(path)
-	if err != nil {
-		return nil, err
-	}
-
-	return obj.GetByteList()
+	return getValue(p, path, (*hocon.HoconValue).GetByteList, nil)
 }
 
 func (p *Config) GetStringList(path string) ([]string, error) {
-	obj, err := p.GetNode(path)
+	return getValue(p, path, (*hocon.HoconValue).GetStringList, nil)
+}
+
+// GetConfig returns the object at the given path as a config, falling back to the
+// object at the same path in the fallback config.
+func (p *Config) GetConfig(path string) (*Config, error) {
+	value, err := p.GetNode(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return obj.GetStringList()
-}
+	config, err := NewConfigFromRoot(hocon.NewHoconRoot(value))
+	if err != nil {
+		return nil, err
+	}
 
-func (p *Config) GetConfig(path string) (*Config, error) {
-	if p == nil {
-		return nil, fmt.Errorf("cannot get config from nil Config")
+	if p.fallback == nil {
+		return config, nil
 	}
 
-	value, err := p.GetNode(path)
+	f, err := p.fallback.GetConfig(path)
+	if errors.Is(err, ErrMissingPath) {
+		return config, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if p.fallback != nil {
-		f, err := p.fallback.GetConfig(path)
+	return config.WithFallback(f)
+}
+
+// GetConfigList returns a config for every object of the array at the given path.
+func (p *Config) GetConfigList(path string) ([]*Config, error) {
+	return getValue(p, path, func(obj *hocon.HoconValue) ([]*Config, error) {
+		arrayV, err := obj.GetList()
 		if err != nil {
 			return nil, err
 		}
 
-		if value == nil && f == nil {
-			return nil, fmt.Errorf("cannot either get value nor perform fallback for path " + path)
-		}
-		if value == nil {
-			return f, nil
-		}
-		root, err := NewConfigFromRoot(hocon.NewHoconRoot(value))
-		if err != nil {
-			return nil, err
+		configs := make([]*Config, 0, len(arrayV))
+		for i, v := range arrayV {
+			if !v.IsObject() {
+				return nil, fmt.Errorf("element %d of %s is not an object", i, path)
+			}
+
+			config, err := NewConfigFromRoot(hocon.NewHoconRoot(v))
+			if err != nil {
+				return nil, err
+			}
+			configs = append(configs, config)
 		}
 
-		return root.WithFallback(f)
+		return configs, nil
+	}, nil)
+}
+
+func (p *Config) GetValue(path string) (*hocon.HoconValue, error) {
+	return p.GetNode(path)
+}
+
+// Comments returns the lines of the comment block written right above the key of
+// the path, nil if there is none or the path cannot be found.
+func (p *Config) Comments(path string) []string {
+	node, err := p.GetNode(path)
+	if err != nil || node == nil {
+		return nil
 	}
 
-	if value == nil {
-		return nil, fmt.Errorf("cannot get value for path " + path)
+	comments := node.Comments()
+	if len(comments) == 0 && p.fallback != nil {
+		return p.fallback.Comments(path)
 	}
-	return NewConfigFromRoot(hocon.NewHoconRoot(value))
+	return append([]string(nil), comments...)
 }
 
-func (p *Config) GetValue(path string) (*hocon.HoconValue, error) {
-	return p.GetNode(path)
+// Unwrapped returns the config as nested maps of plain go values, see
+// hocon.HoconValue.Unwrapped. An empty config gives an empty map.
+func (p *Config) Unwrapped() (map[string]interface{}, error) {
+	if p.IsEmpty() {
+		return map[string]interface{}{}, nil
+	}
+
+	obj, err := p.root.GetObject()
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.Unwrapped()
 }
 
 func (p *Config) WithFallback(fallback *Config) (*Config, error) {
@@ -415,20 +369,39 @@ func (p *Config) AddConfigWithTextFallback(config *Config, textFallback string)
 	return config.WithFallback(fallbackConfig)
 }
 
+// Render returns the text of the config, see hocon.HoconValue.Render.
+func (p *Config) Render(opts ...hocon.RenderOptions) string {
+	if p.IsEmpty() {
+		empty := hocon.NewHoconValue()
+		empty.AppendValue(hocon.NewHoconObject())
+		return empty.Render(opts...)
+	}
+
+	return p.root.Render(opts...)
+}
+
 func (p Config) String() string {
 	return p.root.String()
 }
 
-func splitDottedPathHonouringQuotes(path string) []string {
-	tmp1 := strings.Split(path, "\"")
-	var values []string
-	for i := 0; i < len(tmp1); i++ {
-		tmp2 := strings.Split(tmp1[i], ".")
-		for j := 0; j < len(tmp2); j++ {
-			if len(tmp2[j]) > 0 {
-				values = append(values, tmp2[j])
-			}
-		}
+// Equal checks whether two configs hold the same content, see hocon.HoconValue.Equal.
+// Empty configs are equal to each other.
+func Equal(a, b *Config) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() && b.IsEmpty()
+	}
+
+	return a.root.Equal(b.root)
+}
+
+// Hash returns the canonical hash of the config, equal configs have the same hash.
+// See hocon.HoconValue.CanonicalHash.
+func (p *Config) Hash() string {
+	if p.IsEmpty() {
+		empty := hocon.NewHoconValue()
+		empty.AppendValue(hocon.NewHoconObject())
+		return empty.CanonicalHash()
 	}
-	return values
+
+	return p.root.CanonicalHash()
 }
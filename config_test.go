@@ -0,0 +1,377 @@
+package configuration
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goreflect/go_hocon/hocon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_GetNodeWithQuotedKeys(t *testing.T) {
+	conf, err := ParseString(`
+a {
+  "b.c" { d = 1 }
+  "e f" = 2
+  "" = 3
+}
+g = ${a."b.c".d}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	d, err := conf.GetInt32(`a."b.c".d`)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), d)
+
+	ef, err := conf.GetInt32(`a."e f"`)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), ef)
+
+	empty, err := conf.GetInt32(`a.""`)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), empty)
+
+	g, err := conf.GetInt32("g")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), g)
+
+	node, err := conf.GetNodeByPath(hocon.PathOf("a", "b.c", "d"))
+	if assert.Nil(t, err) {
+		assert.Equal(t, "1", node.String())
+	}
+
+	assert.False(t, conf.HasPath("a.b.c.d"))
+
+	_, err = conf.GetNode("a..b")
+	assert.NotNil(t, err)
+}
+
+func TestConfig_GetNodeWithArrayIndex(t *testing.T) {
+	conf, err := ParseString(`
+servers = [
+  { host = a, port = 80 }
+  { host = b, port = 81 }
+]
+first = ${servers.0.host}
+matrix = [[1, 2], [3, 4]]
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	host, err := conf.GetString("servers.1.host")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", host)
+
+	host, err = conf.GetString("servers[0].host")
+	assert.Nil(t, err)
+	assert.Equal(t, "a", host)
+
+	first, err := conf.GetString("first")
+	assert.Nil(t, err)
+	assert.Equal(t, "a", first)
+
+	cell, err := conf.GetInt32("matrix[1][0]")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), cell)
+
+	assert.False(t, conf.HasPath("servers.2.host"))
+	assert.False(t, conf.HasPath("servers.x"))
+
+	servers, err := conf.GetConfigList("servers")
+	if assert.Nil(t, err) && assert.Len(t, servers, 2) {
+		port, err := servers[1].GetInt32("port")
+		assert.Nil(t, err)
+		assert.Equal(t, int32(81), port)
+	}
+
+	_, err = conf.GetConfigList("matrix")
+	assert.NotNil(t, err)
+
+	_, err = conf.GetString("servers.host")
+	assert.EqualError(t, err, "missing path: servers.host: cannot get key host from an array")
+
+	_, err = conf.GetString("first.x")
+	assert.EqualError(t, err, "missing path: first.x: first is of type string, not an object")
+}
+
+func TestConfig_SubstitutionWithArrayIndex(t *testing.T) {
+	conf, err := ParseString(`
+x = [{ h = a }, { h = b }]
+s = ${x[1].h}
+m = [[1, 2], [3, 4]]
+c = ${m[1][0]}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	s, err := conf.GetString("s")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", s)
+
+	c, err := conf.GetInt32("c")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), c)
+
+	keys, err := conf.Keys("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x", "s", "m", "c"}, keys)
+
+	_, err = ParseString("x = [1]\ns = ${x[0}")
+	assert.NotNil(t, err)
+
+	_, err = ParseString("x = [1]\ns = ${x[a]}")
+	assert.NotNil(t, err)
+}
+
+func TestConfig_ListsFromNumericKeyedObjects(t *testing.T) {
+	properties, err := ParseProperties("servers.1=b\nservers.0=a\nservers.10=c\nports.0=80\nports.1=81\n")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	servers, err := properties.GetStringList("servers")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, servers)
+
+	ports, err := properties.GetInt64List("ports")
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{80, 81}, ports)
+
+	conf, err := ParseString(`
+flags { 1 = off, 0 = on, comment = ignored }
+nodes {
+  0 { host = a }
+  1 { host = b }
+}
+empty { a = b }
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	flags, err := conf.GetBooleanList("flags")
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true, false}, flags)
+
+	nodes, err := conf.GetConfigList("nodes")
+	if assert.Nil(t, err) && assert.Len(t, nodes, 2) {
+		host, err := nodes[1].GetString("host")
+		assert.Nil(t, err)
+		assert.Equal(t, "b", host)
+	}
+
+	_, err = conf.GetStringList("empty")
+	assert.NotNil(t, err)
+}
+
+func TestConfig_Unwrapped(t *testing.T) {
+	conf, err := ParseString(`
+base = 10
+app {
+  name = demo
+  quoted-number = "10"
+  quoted-bool = "true"
+  port = 8080
+  ratio = 0.5
+  big = 1e3
+  huge = 99999999999999999999
+  enabled = true
+  nothing = null
+  greeting = hello world
+  size = ${base}
+  empty {}
+  servers = [{ host = a }, 2, [], "3"]
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	unwrapped, err := conf.Unwrapped()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"base": int64(10),
+		"app": map[string]interface{}{
+			"name":          "demo",
+			"quoted-number": "10",
+			"quoted-bool":   "true",
+			"port":          int64(8080),
+			"ratio":         0.5,
+			"big":           1000.0,
+			"huge":          json.Number("99999999999999999999"),
+			"enabled":       true,
+			"nothing":       nil,
+			"greeting":      "hello world",
+			"size":          int64(10),
+			"empty":         map[string]interface{}{},
+			"servers": []interface{}{
+				map[string]interface{}{"host": "a"},
+				int64(2),
+				[]interface{}{},
+				"3",
+			},
+		},
+	}, unwrapped)
+
+	encoded, err := json.Marshal(unwrapped["app"].(map[string]interface{})["servers"])
+	if assert.Nil(t, err) {
+		assert.Equal(t, `[{"host":"a"},2,[],"3"]`, string(encoded))
+	}
+
+	encoded, err = json.Marshal(unwrapped["app"].(map[string]interface{})["huge"])
+	if assert.Nil(t, err) {
+		assert.Equal(t, `99999999999999999999`, string(encoded))
+	}
+
+	empty, err := (&Config{}).Unwrapped()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{}, empty)
+}
+
+func TestConfig_RenderParsesBackToAnEqualConfig(t *testing.T) {
+	conf, err := ParseString(`
+app {
+  name = "my \"app\""
+  "dotted.key" = yes
+  "" = empty key
+  version = "1.0"
+  port = 8080
+  ratio = 0.5
+  large = 1e21
+  small = 1e-7
+  huge = 99999999999999999999
+  home = ${?HOME}
+  servers = [{ host = "a b", tags = [] }, 2, "null"]
+  "path/to" = "line\nbreak\ttab \\ slash"
+  empty {}
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	want, err := conf.Unwrapped()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	for _, opts := range []hocon.RenderOptions{
+		{},
+		{Formatted: true},
+		{Formatted: true, OriginComments: true, Indent: "\t", Newline: "\r\n"},
+		{JSON: true},
+		{JSON: true, Formatted: true},
+	} {
+		rendered := conf.Render(opts)
+
+		reparsed, err := ParseString(rendered)
+		if !assert.Nil(t, err, rendered) {
+			continue
+		}
+
+		got, err := reparsed.Unwrapped()
+		if assert.Nil(t, err, rendered) {
+			assert.Equal(t, want, got, rendered)
+		}
+
+		if opts.JSON {
+			var decoded map[string]interface{}
+			assert.Nil(t, json.Unmarshal([]byte(rendered), &decoded), rendered)
+		}
+	}
+
+	assert.Equal(t, "{}", (&Config{}).Render())
+}
+
+func TestConfig_Comments(t *testing.T) {
+	reference, err := ParseString(`
+# file header, separated by a blank line
+
+akka {
+  # Akka version, checked against the runtime version of Akka.
+  version = "0.0.1 Akka"
+
+  // Loggers to register at boot time
+  //   indented detail
+  loggers = ["Akka.Event.DefaultLogger"] # trailing, not a description
+  home = ""
+
+  # Dotted keys document their leaf
+  actor.timeout = 1s
+
+  # Objects are documented too
+  debug {
+    # Not this one
+  }
+  # Blank comment line below
+  #
+  loglevel = INFO
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, reference.Comments("akka"))
+	assert.Equal(t, []string{"Akka version, checked against the runtime version of Akka."}, reference.Comments("akka.version"))
+	assert.Equal(t, []string{"Loggers to register at boot time", "  indented detail"}, reference.Comments("akka.loggers"))
+	assert.Nil(t, reference.Comments("akka.home"))
+	assert.Equal(t, []string{"Dotted keys document their leaf"}, reference.Comments("akka.actor.timeout"))
+	assert.Nil(t, reference.Comments("akka.actor"))
+	assert.Equal(t, []string{"Objects are documented too"}, reference.Comments("akka.debug"))
+	assert.Equal(t, []string{"Blank comment line below", ""}, reference.Comments("akka.loglevel"))
+	assert.Nil(t, reference.Comments("akka.missing"))
+
+	application, err := ParseString(`akka.version = "0.0.2"`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conf, err := application.WithFallback(reference)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"Akka version, checked against the runtime version of Akka."}, conf.Comments("akka.version"))
+	assert.Nil(t, application.Comments("akka.version"))
+}
+
+func TestEqualAndHash(t *testing.T) {
+	a, err := ParseString("http { port = 80, host = localhost }")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	b, err := ParseString(`{ "http": { "host": "localhost", "port": 80 } }`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	c, err := ParseString("http { port = 81, host = localhost }")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.True(t, Equal(a, b))
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.Equal(t, 64, len(a.Hash()))
+
+	assert.False(t, Equal(a, c))
+	assert.NotEqual(t, a.Hash(), c.Hash())
+
+	empty, err := ParseString("")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.True(t, Equal(empty, nil))
+	assert.False(t, Equal(empty, a))
+	assert.Equal(t, empty.Hash(), (*Config)(nil).Hash())
+}
@@ -3,6 +3,9 @@ package configuration
 import (
 	"encoding/json"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/goreflect/go_hocon/hocon"
 )
@@ -28,7 +31,18 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, err
 	}
 
-	return ParseString(string(data), defaultIncludeCallback)
+	root, err := hocon.ParseWithOptions(string(data), defaultIncludeCallback, hocon.ParseOptions{Origin: filename})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConfigFromRoot(root)
+}
+
+// LoadFile loads a .conf, .json or .properties file, choosing the syntax by its
+// extension. Unknown extensions are parsed as HOCON.
+func LoadFile(filename string) (*Config, error) {
+	return parseFile(filename, false, true, defaultIncludeCallback)
 }
 
 func FromObject(obj interface{}) (*Config, error) {
@@ -46,5 +60,38 @@ func defaultIncludeCallback(filename string) (*hocon.HoconRoot, error) {
 		return nil, err
 	}
 
-	return hocon.Parse(string(data), defaultIncludeCallback)
+	return hocon.ParseWithOptions(string(data), defaultIncludeCallback, hocon.ParseOptions{Origin: filename})
+}
+
+// parseFile parses the file with the syntax matching its extension, leaving the
+// substitutions unresolved unless resolve is set. A missing file gives a nil config
+// when allowMissing is set.
+func parseFile(filename string, allowMissing, resolve bool, callback hocon.IncludeCallback) (*Config, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if allowMissing && os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if strings.ToLower(filepath.Ext(filename)) == ".properties" {
+		return ParseProperties(string(data), filename)
+	}
+
+	root, err := hocon.ParseWithOptions(string(data), callback, hocon.ParseOptions{
+		Origin:     filename,
+		Unresolved: !resolve,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := NewConfigFromRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	config.unresolved = !resolve
+	return config, nil
 }
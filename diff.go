@@ -0,0 +1,173 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// DiffEntry is a leaf that differs between two configs. OldValue is nil for an added
+// leaf and NewValue is nil for a removed one.
+type DiffEntry struct {
+	Path     hocon.Path
+	OldValue *hocon.HoconValue
+	NewValue *hocon.HoconValue
+}
+
+// ConfigDiff lists the leaves, arrays included, added, removed and changed from a
+// config to another, each in the document order of the config holding them.
+type ConfigDiff struct {
+	Added   []DiffEntry
+	Removed []DiffEntry
+	Changed []DiffEntry
+}
+
+// Diff compares the resolved leaves of two configs, empty objects included so that
+// it agrees with Equal. Leaves are compared with HoconValue.Equal, so that 1 and "1"
+// do not differ and origins and comments are ignored.
+func Diff(a, b *Config) *ConfigDiff {
+	diff := &ConfigDiff{}
+
+	oldEntries := diffEntries(a)
+	oldValues := make(map[string]*hocon.HoconValue, len(oldEntries))
+	for _, entry := range oldEntries {
+		oldValues[entry.Path.Render()] = entry.Value
+	}
+
+	newValues := map[string]bool{}
+	for _, entry := range diffEntries(b) {
+		key := entry.Path.Render()
+		newValues[key] = true
+
+		oldValue, exist := oldValues[key]
+		switch {
+		case !exist:
+			diff.Added = append(diff.Added, DiffEntry{Path: entry.Path, NewValue: entry.Value})
+		case !oldValue.Equal(entry.Value):
+			diff.Changed = append(diff.Changed, DiffEntry{Path: entry.Path, OldValue: oldValue, NewValue: entry.Value})
+		}
+	}
+
+	for _, entry := range oldEntries {
+		if !newValues[entry.Path.Render()] {
+			diff.Removed = append(diff.Removed, DiffEntry{Path: entry.Path, OldValue: entry.Value})
+		}
+	}
+
+	return diff
+}
+
+// diffEntries is like Config.Entries but also returns the empty objects.
+func diffEntries(p *Config) []Entry {
+	var entries []Entry
+
+	p.Walk(func(path hocon.Path, value *hocon.HoconValue) error {
+		if value.IsObject() {
+			obj, err := value.GetObject()
+			// must not return error after checking value.IsObject()
+			if err != nil {
+				panic(err)
+			}
+
+			if len(obj.GetKeys()) > 0 {
+				return nil
+			}
+		}
+
+		entries = append(entries, Entry{Path: path, Value: value})
+		return SkipChildren
+	})
+
+	return entries
+}
+
+// Len returns the number of leaves differing.
+func (p *ConfigDiff) Len() int {
+	return len(p.Added) + len(p.Removed) + len(p.Changed)
+}
+
+// IsEmpty checks whether the configs hold the same leaves.
+func (p *ConfigDiff) IsEmpty() bool {
+	return p.Len() == 0
+}
+
+// String renders the diff as text, a line per leaf prefixed with + when added, - when
+// removed and ~ when changed, followed by the origins of the values that have one.
+func (p *ConfigDiff) String() string {
+	var sb strings.Builder
+
+	for _, entry := range p.Added {
+		fmt.Fprintf(&sb, "+ %s = %s%s\n", entry.Path.Render(), entry.NewValue.Render(), originSuffix(entry.NewValue.Origin()))
+	}
+
+	for _, entry := range p.Removed {
+		fmt.Fprintf(&sb, "- %s = %s%s\n", entry.Path.Render(), entry.OldValue.Render(), originSuffix(entry.OldValue.Origin()))
+	}
+
+	for _, entry := range p.Changed {
+		origin := ""
+		switch oldOrigin, newOrigin := entry.OldValue.Origin().String(), entry.NewValue.Origin().String(); {
+		case len(oldOrigin) > 0 && len(newOrigin) > 0:
+			origin = fmt.Sprintf(" (%s -> %s)", oldOrigin, newOrigin)
+		case len(oldOrigin) > 0:
+			origin = originSuffix(entry.OldValue.Origin())
+		default:
+			origin = originSuffix(entry.NewValue.Origin())
+		}
+		fmt.Fprintf(&sb, "~ %s = %s -> %s%s\n", entry.Path.Render(), entry.OldValue.Render(), entry.NewValue.Render(), origin)
+	}
+
+	return sb.String()
+}
+
+func originSuffix(origin *hocon.HoconOrigin) string {
+	if text := origin.String(); len(text) > 0 {
+		return " (" + text + ")"
+	}
+	return ""
+}
+
+type jsonDiff struct {
+	Added   []jsonDiffEntry `json:"added"`
+	Removed []jsonDiffEntry `json:"removed"`
+	Changed []jsonDiffEntry `json:"changed"`
+}
+
+type jsonDiffEntry struct {
+	Path      string          `json:"path"`
+	OldValue  json.RawMessage `json:"old,omitempty"`
+	OldOrigin string          `json:"oldOrigin,omitempty"`
+	NewValue  json.RawMessage `json:"new,omitempty"`
+	NewOrigin string          `json:"newOrigin,omitempty"`
+}
+
+// MarshalJSON renders the diff as a JSON object with added, removed and changed
+// arrays. Their entries hold the path, the old and new values as JSON, and the
+// origins of the values.
+func (p *ConfigDiff) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonDiff{
+		Added:   jsonDiffEntries(p.Added),
+		Removed: jsonDiffEntries(p.Removed),
+		Changed: jsonDiffEntries(p.Changed),
+	})
+}
+
+func jsonDiffEntries(entries []DiffEntry) []jsonDiffEntry {
+	jsonEntries := make([]jsonDiffEntry, len(entries))
+	for i, entry := range entries {
+		jsonEntries[i].Path = entry.Path.Render()
+
+		if entry.OldValue != nil {
+			jsonEntries[i].OldValue = json.RawMessage(entry.OldValue.Render(hocon.RenderOptions{JSON: true}))
+			jsonEntries[i].OldOrigin = entry.OldValue.Origin().String()
+		}
+
+		if entry.NewValue != nil {
+			jsonEntries[i].NewValue = json.RawMessage(entry.NewValue.Render(hocon.RenderOptions{JSON: true}))
+			jsonEntries[i].NewOrigin = entry.NewValue.Origin().String()
+		}
+	}
+	return jsonEntries
+}
@@ -0,0 +1,127 @@
+package configuration
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goreflect/go_hocon/hocon"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseWithOrigin(t *testing.T, text, origin string) *Config {
+	root, err := hocon.ParseWithOptions(text, nil, hocon.ParseOptions{Origin: origin})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err := NewConfigFromRoot(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conf
+}
+
+func TestDiff(t *testing.T) {
+	a := parseWithOrigin(t, "http {\n  port = 80\n  host = localhost\n}\ntags = [a, b]\ndebug = 1\n", "a.conf")
+	b := parseWithOrigin(t, "http {\n  port = 8080\n  host = localhost\n  tls = on\n}\ntags = [a, b]\ndebug = \"1\"\n", "b.conf")
+
+	diff := Diff(a, b)
+	assert.Equal(t, 2, diff.Len())
+	assert.False(t, diff.IsEmpty())
+
+	if assert.Equal(t, 1, len(diff.Added)) {
+		assert.Equal(t, "http.tls", diff.Added[0].Path.Render())
+		assert.Nil(t, diff.Added[0].OldValue)
+	}
+	assert.Empty(t, diff.Removed)
+	if assert.Equal(t, 1, len(diff.Changed)) {
+		assert.Equal(t, "http.port", diff.Changed[0].Path.Render())
+	}
+
+	assert.Equal(t, `+ http.tls = "on" (b.conf: 4)
+~ http.port = 80 -> 8080 (a.conf: 2 -> b.conf: 2)
+`, diff.String())
+
+	reverse := Diff(b, a)
+	if assert.Equal(t, 1, len(reverse.Removed)) {
+		assert.Equal(t, "http.tls", reverse.Removed[0].Path.Render())
+	}
+	assert.Empty(t, reverse.Added)
+
+	assert.True(t, Diff(a, a).IsEmpty())
+}
+
+func TestDiff_EnvOverrides(t *testing.T) {
+	a, err := ParseString("a = 2\nb = 1")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	overrides, err := EnvOverrides(EnvOptions{Environ: []string{"CONFIG_FORCE_a=3", "CONFIG_FORCE_b=1"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	b, err := overrides.WithFallback(a)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	diff := Diff(a, b)
+	assert.Equal(t, 1, diff.Len())
+	assert.Equal(t, "~ a = 2 -> \"3\" (env variable CONFIG_FORCE_a)\n", diff.String())
+}
+
+func TestDiff_ObjectReplacedByLeaf(t *testing.T) {
+	a, err := ParseString("a { b = 1, c = [1] }")
+	if !assert.Nil(t, err) {
+		return
+	}
+	b, err := ParseString("a = 1")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	diff := Diff(a, b)
+	assert.Equal(t, "+ a = 1\n- a.b = 1\n- a.c = [1]\n", diff.String())
+}
+
+func TestDiff_EmptyObjects(t *testing.T) {
+	a, err := ParseString("a = {}")
+	if !assert.Nil(t, err) {
+		return
+	}
+	b, err := ParseString("b = {}")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	diff := Diff(a, b)
+	assert.False(t, Equal(a, b))
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, "+ b = {}\n- a = {}\n", diff.String())
+
+	c, err := ParseString("a { x = 1 }")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "+ a.x = 1\n- a = {}\n", Diff(a, c).String())
+
+	assert.True(t, Diff(a, a).IsEmpty())
+}
+
+func TestConfigDiff_MarshalJSON(t *testing.T) {
+	a := parseWithOrigin(t, "a = 1\nb { c = [1, 2] }\n", "a.conf")
+	b := parseWithOrigin(t, "a = 2\nd = x\n", "b.conf")
+
+	data, err := json.Marshal(Diff(a, b))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.JSONEq(t, `{
+  "added": [{"path": "d", "new": "x", "newOrigin": "b.conf: 2"}],
+  "removed": [{"path": "b.c", "old": [1, 2], "oldOrigin": "a.conf: 2"}],
+  "changed": [{"path": "a", "old": 1, "oldOrigin": "a.conf: 1", "new": 2, "newOrigin": "b.conf: 1"}]
+}`, string(data))
+}
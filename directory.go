@@ -0,0 +1,77 @@
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+var directoryExtensions = []string{".conf", ".json", ".properties"}
+
+// DirectoryOptions tunes LoadDirectory.
+type DirectoryOptions struct {
+	// AllowMissing gives an empty config instead of an error when the directory does not exist.
+	AllowMissing bool
+	// IncludeCallback loads included files, the default one reads them from disk.
+	IncludeCallback hocon.IncludeCallback
+}
+
+// LoadDirectory parses every .conf, .json and .properties file of the directory in
+// lexical order and layers them so that a later file overrides an earlier one, e.g.
+// conf.d/99-local.conf overrides conf.d/10-base.conf. Substitutions are resolved once
+// against the merged tree and every value keeps its file as origin.
+func LoadDirectory(dir string, opts ...DirectoryOptions) (*Config, error) {
+	var opt DirectoryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	callback := opt.IncludeCallback
+	if callback == nil {
+		callback = defaultIncludeCallback
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if opt.AllowMissing && os.IsNotExist(err) {
+			return newConfigFromObject(hocon.NewHoconObject())
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() || !hasDirectoryExtension(info.Name()) {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	sort.Strings(names)
+
+	layers := make([]*Config, len(names))
+	for i, name := range names {
+		layer, err := parseFile(filepath.Join(dir, name), false, false, callback)
+		if err != nil {
+			return nil, err
+		}
+
+		// the last file has the highest precedence
+		layers[len(names)-1-i] = layer
+	}
+
+	return mergeAndResolve(layers)
+}
+
+func hasDirectoryExtension(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, known := range directoryExtensions {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
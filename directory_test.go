@@ -0,0 +1,63 @@
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go_hocon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	base := writeTestFile(t, dir, "10-base.conf", `
+app {
+  region = default
+  port = 80
+  url = "http://"${app.region}".example.com"
+}`)
+	writeTestFile(t, dir, "50-region.json", `{"app": {"region": "eu"}}`)
+	local := writeTestFile(t, dir, "99-local.properties", "app.port=8080\n")
+	writeTestFile(t, dir, "README.md", "not a config")
+
+	conf, err := LoadDirectory(dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	url, err := conf.GetString("app.url")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://eu.example.com", url)
+
+	port, err := conf.GetInt32("app.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8080), port)
+
+	portValue, err := conf.GetValue("app.port")
+	if assert.Nil(t, err) {
+		assert.Equal(t, local, portValue.Origin().Description())
+	}
+
+	urlValue, err := conf.GetValue("app.url")
+	if assert.Nil(t, err) {
+		assert.Equal(t, base+": 5", urlValue.Origin().String())
+	}
+}
+
+func TestLoadDirectory_Missing(t *testing.T) {
+	missing := filepath.Join(os.TempDir(), "go_hocon_missing_dir")
+
+	_, err := LoadDirectory(missing)
+	assert.NotNil(t, err)
+
+	conf, err := LoadDirectory(missing, DirectoryOptions{AllowMissing: true})
+	if assert.Nil(t, err) {
+		assert.True(t, conf.IsEmpty())
+	}
+}
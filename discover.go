@@ -0,0 +1,115 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// DiscoverOptions tunes Discover.
+type DiscoverOptions struct {
+	// WorkingDir is searched first, the current directory if empty.
+	WorkingDir string
+	// HomeDir replaces the user home directory if set.
+	HomeDir string
+	// LookupEnv reads the XDG variables, os.LookupEnv if nil.
+	LookupEnv func(key string) (string, bool)
+	// IncludeCallback loads included files, the default one reads them from disk.
+	IncludeCallback hocon.IncludeCallback
+}
+
+// DiscoveryPaths lists where Discover looks for <app>.conf, from the highest
+// precedence to the lowest: the working directory, $XDG_CONFIG_HOME/<app>,
+// ~/.config/<app>, every $XDG_CONFIG_DIRS/<app> and /etc/<app>.
+func DiscoveryPaths(app string, opts ...DiscoverOptions) []string {
+	var opt DiscoverOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	lookupEnv := opt.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+
+	homeDir := opt.HomeDir
+	if len(homeDir) == 0 {
+		homeDir, _ = os.UserHomeDir()
+	}
+
+	filename := app + ".conf"
+	var dirs []string
+
+	workingDir := opt.WorkingDir
+	if len(workingDir) == 0 {
+		workingDir = "."
+	}
+	dirs = append(dirs, workingDir)
+
+	if configHome, exist := lookupEnv("XDG_CONFIG_HOME"); exist && len(configHome) > 0 {
+		dirs = append(dirs, filepath.Join(configHome, app))
+	}
+
+	if len(homeDir) > 0 {
+		dirs = append(dirs, filepath.Join(homeDir, ".config", app))
+	}
+
+	configDirs, exist := lookupEnv("XDG_CONFIG_DIRS")
+	if !exist || len(configDirs) == 0 {
+		configDirs = "/etc/xdg"
+	}
+	for _, dir := range strings.Split(configDirs, string(os.PathListSeparator)) {
+		if len(dir) > 0 {
+			dirs = append(dirs, filepath.Join(dir, app))
+		}
+	}
+
+	dirs = append(dirs, filepath.Join("/etc", app))
+
+	var paths []string
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, filename)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// Discover merges every <app>.conf found in the DiscoveryPaths, a file found earlier
+// overriding the ones found later, and resolves the substitutions once. It also
+// returns the files used, from the highest precedence to the lowest.
+func Discover(app string, opts ...DiscoverOptions) (*Config, []string, error) {
+	callback := defaultIncludeCallback
+	if len(opts) > 0 && opts[0].IncludeCallback != nil {
+		callback = opts[0].IncludeCallback
+	}
+
+	var layers []*Config
+	var used []string
+
+	for _, path := range DiscoveryPaths(app, opts...) {
+		layer, err := parseFile(path, true, false, callback)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if layer != nil {
+			layers = append(layers, layer)
+			used = append(used, path)
+		}
+	}
+
+	config, err := mergeAndResolve(layers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, used, nil
+}
@@ -0,0 +1,78 @@
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoveryPaths(t *testing.T) {
+	env := map[string]string{
+		"XDG_CONFIG_HOME": "/home/user/.config",
+		"XDG_CONFIG_DIRS": "/opt/xdg" + string(os.PathListSeparator) + "/etc/xdg",
+	}
+
+	paths := DiscoveryPaths("app", DiscoverOptions{
+		WorkingDir: "/srv",
+		HomeDir:    "/home/user",
+		LookupEnv: func(key string) (string, bool) {
+			value, exist := env[key]
+			return value, exist
+		},
+	})
+
+	assert.Equal(t, []string{
+		"/srv/app.conf",
+		"/home/user/.config/app/app.conf",
+		"/opt/xdg/app/app.conf",
+		"/etc/xdg/app/app.conf",
+		"/etc/app/app.conf",
+	}, paths)
+}
+
+func TestDiscover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go_hocon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	workingDir := filepath.Join(dir, "work")
+	homeConfig := filepath.Join(dir, "home", ".config", "app")
+	systemConfig := filepath.Join(dir, "xdg", "app")
+	for _, d := range []string{workingDir, homeConfig, systemConfig} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	local := writeTestFile(t, workingDir, "app.conf", `server.port = 8080`)
+	system := writeTestFile(t, systemConfig, "app.conf", `server { port = 80, host = ${server.name}".local", name = app }`)
+
+	conf, used, err := Discover("app", DiscoverOptions{
+		WorkingDir: workingDir,
+		HomeDir:    filepath.Join(dir, "home"),
+		LookupEnv: func(key string) (string, bool) {
+			if key == "XDG_CONFIG_DIRS" {
+				return filepath.Join(dir, "xdg"), true
+			}
+			return "", false
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{local, system}, used)
+
+	port, err := conf.GetInt32("server.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8080), port)
+
+	host, err := conf.GetString("server.host")
+	assert.Nil(t, err)
+	assert.Equal(t, "app.local", host)
+}
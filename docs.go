@@ -0,0 +1,214 @@
+package configuration
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// DocGroup lists the settings of an object, as documented by the generators.
+type DocGroup struct {
+	// Path is the path of the object, empty for the root.
+	Path        hocon.Path
+	Description string
+	Settings    []DocSetting
+}
+
+// DocSetting is a documented leaf of a config.
+type DocSetting struct {
+	Path hocon.Path
+	// Default is the value rendered as concise HOCON.
+	Default string
+	// Type is one of string, duration, size, integer, number, boolean, null and list.
+	Type        string
+	Description string
+}
+
+// DocsOptions tunes the documentation generators.
+type DocsOptions struct {
+	// Title is written as the main heading when set.
+	Title string
+}
+
+// DocGroups lists every leaf of the config with its default value, its type and the
+// comments written above it, grouped by the object holding it. The groups and the
+// settings keep the document order.
+func (p *Config) DocGroups() []DocGroup {
+	var groups []DocGroup
+	indices := map[string]int{}
+
+	for _, entry := range p.Entries() {
+		parent := entry.Path.Parent()
+		key := parent.Render()
+
+		index, exist := indices[key]
+		if !exist {
+			index = len(groups)
+			indices[key] = index
+
+			group := DocGroup{Path: parent}
+			if len(parent) > 0 {
+				group.Description = joinComments(p.Comments(key))
+			}
+			groups = append(groups, group)
+		}
+
+		groups[index].Settings = append(groups[index].Settings, DocSetting{
+			Path:        entry.Path,
+			Default:     entry.Value.Render(),
+			Type:        docType(entry.Value),
+			Description: joinComments(p.Comments(entry.Path.Render())),
+		})
+	}
+
+	return groups
+}
+
+// WriteMarkdownDocs writes the DocGroups of the config as Markdown, a section with a
+// table of settings per group.
+func (p *Config) WriteMarkdownDocs(w io.Writer, opts ...DocsOptions) error {
+	var opt DocsOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	bw := bufio.NewWriter(w)
+	if len(opt.Title) > 0 {
+		fmt.Fprintf(bw, "# %s\n\n", opt.Title)
+	}
+
+	for _, group := range p.DocGroups() {
+		fmt.Fprintf(bw, "## %s\n\n", markdownText(groupTitle(group)))
+		if len(group.Description) > 0 {
+			fmt.Fprintf(bw, "%s\n\n", markdownText(group.Description))
+		}
+
+		bw.WriteString("| Setting | Default | Type | Description |\n")
+		bw.WriteString("| --- | --- | --- | --- |\n")
+		for _, setting := range group.Settings {
+			fmt.Fprintf(bw, "| %s | %s | %s | %s |\n",
+				markdownCode(setting.Path.Render()),
+				markdownCode(setting.Default),
+				setting.Type,
+				markdownText(setting.Description))
+		}
+		bw.WriteString("\n")
+	}
+
+	return bw.Flush()
+}
+
+// WriteHTMLDocs writes the DocGroups of the config as an HTML fragment, a section with
+// a table of settings per group.
+func (p *Config) WriteHTMLDocs(w io.Writer, opts ...DocsOptions) error {
+	var opt DocsOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	bw := bufio.NewWriter(w)
+	if len(opt.Title) > 0 {
+		fmt.Fprintf(bw, "<h1>%s</h1>\n", html.EscapeString(opt.Title))
+	}
+
+	for _, group := range p.DocGroups() {
+		if len(group.Path) == 0 {
+			fmt.Fprintf(bw, "<h2>%s</h2>\n", html.EscapeString(groupTitle(group)))
+		} else {
+			fmt.Fprintf(bw, "<h2 id=\"%s\">%s</h2>\n",
+				html.EscapeString(group.Path.Render()), html.EscapeString(groupTitle(group)))
+		}
+		if len(group.Description) > 0 {
+			fmt.Fprintf(bw, "<p>%s</p>\n", html.EscapeString(group.Description))
+		}
+
+		bw.WriteString("<table>\n")
+		bw.WriteString("<thead><tr><th>Setting</th><th>Default</th><th>Type</th><th>Description</th></tr></thead>\n")
+		bw.WriteString("<tbody>\n")
+		for _, setting := range group.Settings {
+			fmt.Fprintf(bw, "<tr><td><code>%s</code></td><td><code>%s</code></td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(setting.Path.Render()),
+				html.EscapeString(setting.Default),
+				setting.Type,
+				html.EscapeString(setting.Description))
+		}
+		bw.WriteString("</tbody>\n</table>\n")
+	}
+
+	return bw.Flush()
+}
+
+func groupTitle(group DocGroup) string {
+	if len(group.Path) == 0 {
+		return "Top level settings"
+	}
+	return group.Path.Render()
+}
+
+func joinComments(comments []string) string {
+	var words []string
+	for _, comment := range comments {
+		if text := strings.TrimSpace(comment); len(text) > 0 {
+			words = append(words, text)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func docType(value *hocon.HoconValue) string {
+	if value.IsArray() {
+		return "list"
+	}
+
+	unwrapped, err := value.Unwrapped()
+	if err != nil {
+		return "string"
+	}
+
+	switch v := unwrapped.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int64, json.Number:
+		return "integer"
+	case float64:
+		return "number"
+	case string:
+		if _, err := strconv.ParseFloat(v, 64); err == nil || len(v) == 0 {
+			return "string"
+		}
+		if _, err := value.GetTimeDuration(true); err == nil {
+			return "duration"
+		}
+		if _, err := value.GetByteSize(); err == nil {
+			return "size"
+		}
+	}
+	return "string"
+}
+
+// markdownText escapes text for a table cell.
+func markdownText(text string) string {
+	text = strings.Replace(text, "\n", " ", -1)
+	return strings.Replace(text, "|", `\|`, -1)
+}
+
+// markdownCode wraps text in a code span fitting in a table cell.
+func markdownCode(text string) string {
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	if len(fence) > 1 {
+		text = " " + text + " "
+	}
+	return fence + markdownText(text) + fence
+}
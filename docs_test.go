@@ -0,0 +1,103 @@
+package configuration
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const docsConfig = `
+# Name shown to users
+name = "demo | app"
+
+# HTTP server settings
+http {
+  # Port to listen on
+  port = 8080
+  # Time to wait for a request
+  # before giving up
+  timeout = 5s
+  max-body = 10M
+  hosts = [a, b]
+  secure = off
+  ratio = 0.5
+  version = "10"
+}
+`
+
+func TestConfig_DocGroups(t *testing.T) {
+	conf, err := ParseString(docsConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	groups := conf.DocGroups()
+	if !assert.Equal(t, 2, len(groups)) {
+		return
+	}
+
+	assert.Equal(t, "", groups[0].Path.Render())
+	assert.Equal(t, "", groups[0].Description)
+	if assert.Equal(t, 1, len(groups[0].Settings)) {
+		assert.Equal(t, DocSetting{
+			Path:        groups[0].Settings[0].Path,
+			Default:     `"demo | app"`,
+			Type:        "string",
+			Description: "Name shown to users",
+		}, groups[0].Settings[0])
+		assert.Equal(t, "name", groups[0].Settings[0].Path.Render())
+	}
+
+	assert.Equal(t, "http", groups[1].Path.Render())
+	assert.Equal(t, "HTTP server settings", groups[1].Description)
+
+	var paths, defaults, types, descriptions []string
+	for _, setting := range groups[1].Settings {
+		paths = append(paths, setting.Path.Render())
+		defaults = append(defaults, setting.Default)
+		types = append(types, setting.Type)
+		descriptions = append(descriptions, setting.Description)
+	}
+
+	assert.Equal(t, []string{"http.port", "http.timeout", "http.max-body", "http.hosts", "http.secure", "http.ratio", "http.version"}, paths)
+	assert.Equal(t, []string{"8080", `"5s"`, `"10M"`, `["a","b"]`, `"off"`, "0.5", `"10"`}, defaults)
+	assert.Equal(t, []string{"integer", "duration", "size", "list", "string", "number", "string"}, types)
+	assert.Equal(t, []string{"Port to listen on", "Time to wait for a request before giving up", "", "", "", "", ""}, descriptions)
+}
+
+func TestConfig_WriteMarkdownDocs(t *testing.T) {
+	conf, err := ParseString(docsConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if !assert.Nil(t, conf.WriteMarkdownDocs(&buf, DocsOptions{Title: "Demo"})) {
+		return
+	}
+
+	text := buf.String()
+	assert.Contains(t, text, "# Demo\n\n## Top level settings\n\n| Setting | Default | Type | Description |\n| --- | --- | --- | --- |\n")
+	assert.Contains(t, text, "| `name` | `\"demo \\| app\"` | string | Name shown to users |\n")
+	assert.Contains(t, text, "## http\n\nHTTP server settings\n\n")
+	assert.Contains(t, text, "| `http.timeout` | `\"5s\"` | duration | Time to wait for a request before giving up |\n")
+}
+
+func TestConfig_WriteHTMLDocs(t *testing.T) {
+	conf, err := ParseString(docsConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if !assert.Nil(t, conf.WriteHTMLDocs(&buf, DocsOptions{Title: "<Demo>"})) {
+		return
+	}
+
+	text := buf.String()
+	assert.Contains(t, text, "<h1>&lt;Demo&gt;</h1>\n")
+	assert.Contains(t, text, "<h2>Top level settings</h2>\n")
+	assert.Contains(t, text, "<tr><td><code>name</code></td><td><code>&#34;demo | app&#34;</code></td><td>string</td><td>Name shown to users</td></tr>\n")
+	assert.Contains(t, text, "<h2 id=\"http\">http</h2>\n<p>HTTP server settings</p>\n")
+}
@@ -0,0 +1,164 @@
+package configuration
+
+import (
+	"errors"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// WithValue returns a new config where the path holds the value. The receiver is
+// never modified, the unchanged parts of the tree are shared.
+func (p *Config) WithValue(path string, value *hocon.HoconValue) (*Config, error) {
+	parsedPath, err := p.editablePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := p.root.WithPathValue(parsedPath, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.withRoot(root), nil
+}
+
+// WithoutPath returns a new config where the path is removed. The receiver is never
+// modified.
+func (p *Config) WithoutPath(path string) (*Config, error) {
+	parsedPath, err := p.editablePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := p.mergedRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := merged.WithoutPath(parsedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.withMergedRoot(root), nil
+}
+
+// WithOnlyPath returns a new config holding only the given path, which is empty when
+// the path cannot be found. The receiver is never modified.
+func (p *Config) WithOnlyPath(path string) (*Config, error) {
+	parsedPath, err := p.editablePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	root := hocon.NewHoconValue()
+	root.AppendValue(hocon.NewHoconObject())
+
+	merged, err := p.mergedRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := merged.GetNode(parsedPath)
+	if err != nil || value == nil {
+		return p.withMergedRoot(root), nil
+	}
+
+	root, err = root.WithPathValue(parsedPath, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.withMergedRoot(root), nil
+}
+
+// AtPath returns a new config where the root of the receiver is placed at the given path.
+func (p *Config) AtPath(path string) (*Config, error) {
+	parsedPath, err := p.editablePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := hocon.NewHoconValue().WithPathValue(parsedPath, p.root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{root: root, substitutions: p.substitutions}, nil
+}
+
+// AtKey returns a new config where the root of the receiver is placed under the key.
+func (p *Config) AtKey(key string) (*Config, error) {
+	if p == nil || p.root == nil {
+		return nil, errors.New("cannot edit nil Config")
+	}
+
+	root, err := hocon.NewHoconValue().WithPathValue(hocon.PathOf(key), p.root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{root: root, substitutions: p.substitutions}, nil
+}
+
+func (p *Config) editablePath(path string) (hocon.Path, error) {
+	if p == nil || p.root == nil {
+		return nil, errors.New("cannot edit nil Config")
+	}
+
+	parsedPath, err := hocon.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parsedPath) == 0 {
+		return nil, errors.New("cannot edit the root of a Config")
+	}
+
+	return parsedPath, nil
+}
+
+func (p *Config) withRoot(root *hocon.HoconValue) *Config {
+	return &Config{
+		root:          root,
+		substitutions: p.substitutions,
+		fallback:      p.fallback,
+	}
+}
+
+// withMergedRoot is like withRoot but drops the fallback, for a root edited from
+// mergedRoot, so that the paths removed from the root cannot be read through it.
+func (p *Config) withMergedRoot(root *hocon.HoconValue) *Config {
+	return &Config{
+		root:          root,
+		substitutions: p.substitutions,
+	}
+}
+
+// mergedRoot returns the root merged with the fallback configs. WithFallback already
+// merges its fallback into the root but NewConfigFromConfig does not, merging it
+// again changes nothing.
+func (p *Config) mergedRoot() (*hocon.HoconValue, error) {
+	if p.fallback.IsEmpty() {
+		return p.root, nil
+	}
+
+	fallbackRoot, err := p.fallback.mergedRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := p.root.GetObject()
+	if err != nil {
+		return nil, err
+	}
+
+	fallbackObj, err := fallbackRoot.GetObject()
+	if err != nil {
+		return nil, err
+	}
+
+	root := hocon.NewHoconValue()
+	root.AppendValue(obj.MergeImmutable(fallbackObj))
+	return root, nil
+}
@@ -0,0 +1,239 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/goreflect/go_hocon/hocon"
+	"github.com/stretchr/testify/assert"
+)
+
+const editTestConfig = `
+app {
+  name = demo
+  http { port = 80, host = localhost }
+  servers = [{ host = a }, { host = b }]
+}
+version = 1
+`
+
+func literalValue(text string) *hocon.HoconValue {
+	value := hocon.NewHoconValue()
+	value.AppendValue(hocon.NewHoconLiteral(text))
+	return value
+}
+
+func TestConfig_WithValue(t *testing.T) {
+	conf, err := ParseString(editTestConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+	before := conf.String()
+
+	edited, err := conf.WithValue("app.http.port", literalValue("8080"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	edited, err = edited.WithValue("app.tls.enabled", literalValue("on"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	edited, err = edited.WithValue("app.servers.1.host", literalValue("c"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	edited, err = edited.WithValue("version.major", literalValue("2"))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, before, conf.String())
+
+	port, err := edited.GetInt32("app.http.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8080), port)
+
+	host, err := edited.GetString("app.http.host")
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", host)
+
+	tls, err := edited.GetBoolean("app.tls.enabled")
+	assert.Nil(t, err)
+	assert.True(t, tls)
+
+	server, err := edited.GetString("app.servers.1.host")
+	assert.Nil(t, err)
+	assert.Equal(t, "c", server)
+
+	major, err := edited.GetInt32("version.major")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), major)
+
+	keys, err := edited.Keys("app")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"name", "http", "servers", "tls"}, keys)
+
+	_, err = conf.WithValue("app.servers.5.host", literalValue("x"))
+	assert.NotNil(t, err)
+
+	_, err = conf.WithValue("", literalValue("x"))
+	assert.NotNil(t, err)
+}
+
+func TestConfig_WithoutPath(t *testing.T) {
+	conf, err := ParseString(editTestConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+	before := conf.String()
+
+	edited, err := conf.WithoutPath("app.http.port")
+	if !assert.Nil(t, err) {
+		return
+	}
+	edited, err = edited.WithoutPath("app.servers.0")
+	if !assert.Nil(t, err) {
+		return
+	}
+	edited, err = edited.WithoutPath("app.missing.key")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, before, conf.String())
+	assert.False(t, edited.HasPath("app.http.port"))
+	assert.True(t, edited.HasPath("app.http.host"))
+
+	hosts, err := edited.GetConfigList("app.servers")
+	if assert.Nil(t, err) && assert.Len(t, hosts, 1) {
+		host, err := hosts[0].GetString("host")
+		assert.Nil(t, err)
+		assert.Equal(t, "b", host)
+	}
+}
+
+func TestConfig_EditMergedConfig(t *testing.T) {
+	conf, err := ParseString("x = 1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	fallback, err := ParseString("w = 2")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	merged, err := conf.WithFallback(fallback)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	without, err := merged.WithoutPath("w")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, without.HasPath("w"))
+	assert.True(t, without.HasPath("x"))
+	_, err = without.GetString("w")
+	assert.NotNil(t, err)
+
+	only, err := merged.WithOnlyPath("x")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, only.HasPath("w"))
+	assert.True(t, only.HasPath("x"))
+
+	assert.True(t, merged.HasPath("w"))
+}
+
+func TestConfig_EditConfigWithFallback(t *testing.T) {
+	conf, err := ParseString("x = 1\nobj { a = 1 }")
+	if !assert.Nil(t, err) {
+		return
+	}
+	fallback, err := ParseString("w = 2\ny = 3\nobj { b = 2 }")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	layered, err := NewConfigFromConfig(conf, fallback)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	without, err := layered.WithoutPath("y")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, without.HasPath("y"))
+	assert.True(t, without.HasPath("x"))
+	assert.True(t, without.HasPath("w"))
+	assert.True(t, without.HasPath("obj.b"))
+
+	without, err = layered.WithoutPath("obj.b")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, without.HasPath("obj.b"))
+	assert.True(t, without.HasPath("obj.a"))
+
+	only, err := layered.WithOnlyPath("w")
+	if !assert.Nil(t, err) {
+		return
+	}
+	if value, err := only.GetInt32("w"); assert.Nil(t, err) {
+		assert.Equal(t, int32(2), value)
+	}
+	assert.False(t, only.HasPath("x"))
+
+	only, err = layered.WithOnlyPath("obj")
+	if !assert.Nil(t, err) {
+		return
+	}
+	keys, err := only.Keys("obj")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+}
+
+func TestConfig_WithOnlyPathAndAtPath(t *testing.T) {
+	conf, err := ParseString(editTestConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	only, err := conf.WithOnlyPath("app.http")
+	if !assert.Nil(t, err) {
+		return
+	}
+	keys, err := only.Keys("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"app"}, keys)
+	keys, err = only.Keys("app")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"http"}, keys)
+
+	missing, err := conf.WithOnlyPath("app.missing")
+	if assert.Nil(t, err) {
+		assert.True(t, missing.IsEmpty())
+	}
+
+	http, err := conf.GetConfig("app.http")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	moved, err := http.AtPath("server.http")
+	if !assert.Nil(t, err) {
+		return
+	}
+	port, err := moved.GetInt32("server.http.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(80), port)
+
+	keyed, err := http.AtKey("a.b")
+	if !assert.Nil(t, err) {
+		return
+	}
+	port, err = keyed.GetInt32(`"a.b".port`)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(80), port)
+}
@@ -0,0 +1,146 @@
+package configuration
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+const defaultEnvOverridePrefix = "CONFIG_FORCE_"
+
+// EnvOptions controls how environment variables are turned into config overrides.
+type EnvOptions struct {
+	// Prefix selects the variables to use, CONFIG_FORCE_ if empty.
+	Prefix string
+	// Mangle converts a variable name without the prefix into a config path,
+	// MangleEnvName if nil. An empty result skips the variable.
+	Mangle func(name string) string
+	// Environ is the list of KEY=value pairs to read, os.Environ() if nil.
+	Environ []string
+}
+
+// EnvOverrides builds a config out of the environment variables starting with the
+// configured prefix, e.g. CONFIG_FORCE_akka_loglevel=DEBUG sets akka.loglevel.
+// The result is meant to be layered above the application config:
+//
+//	overrides.WithFallback(application)
+func EnvOverrides(opts ...EnvOptions) (*Config, error) {
+	var opt EnvOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	prefix := opt.Prefix
+	if len(prefix) == 0 {
+		prefix = defaultEnvOverridePrefix
+	}
+
+	mangle := opt.Mangle
+	if mangle == nil {
+		mangle = MangleEnvName
+	}
+
+	environ := opt.Environ
+	if environ == nil {
+		environ = os.Environ()
+	}
+
+	names := make([]string, 0, len(environ))
+	values := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		idx := strings.IndexByte(kv, '=')
+		if idx < 0 || !strings.HasPrefix(kv[:idx], prefix) {
+			continue
+		}
+
+		name := kv[:idx]
+		if _, exist := values[name]; !exist {
+			names = append(names, name)
+		}
+		values[name] = kv[idx+1:]
+	}
+	sort.Strings(names)
+
+	root := hocon.NewHoconObject()
+	for _, name := range names {
+		path := mangle(strings.TrimPrefix(name, prefix))
+		if len(path) == 0 {
+			continue
+		}
+
+		origin := hocon.NewHoconOrigin("env variable "+name, 0)
+		setPathString(root, hocon.PathOf(strings.Split(path, ".")...), values[name], origin)
+	}
+
+	return newConfigFromObject(root)
+}
+
+// MangleEnvName converts an environment variable name into a config path the same
+// way typesafe config does: a single underscore becomes a dot, two underscores
+// become a dash and three underscores become an underscore.
+func MangleEnvName(name string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(name); {
+		if name[i] != '_' {
+			sb.WriteByte(name[i])
+			i++
+			continue
+		}
+
+		run := 0
+		for i < len(name) && name[i] == '_' {
+			run++
+			i++
+		}
+
+		for ; run >= 3; run -= 3 {
+			sb.WriteByte('_')
+		}
+
+		switch run {
+		case 2:
+			sb.WriteByte('-')
+		case 1:
+			sb.WriteByte('.')
+		}
+	}
+
+	return sb.String()
+}
+
+func newConfigFromObject(obj *hocon.HoconObject) (*Config, error) {
+	value := hocon.NewHoconValue()
+	value.AppendValue(obj)
+	return NewConfigFromRoot(hocon.NewHoconRoot(value))
+}
+
+// setPathString stores text under the given keys, creating intermediate objects and
+// replacing any non-object value found on the way. The text is kept as a string, the
+// getters still convert it.
+func setPathString(obj *hocon.HoconObject, keys hocon.Path, text string, origin *hocon.HoconOrigin) {
+	if len(keys) == 0 {
+		return
+	}
+
+	for _, key := range keys[:len(keys)-1] {
+		child := obj.GetKey(key)
+		if child == nil || !child.IsObject() {
+			child = obj.GetOrCreateKey(key)
+			child.NewValue(hocon.NewHoconObject())
+		}
+
+		childObj, err := child.GetObject()
+		// must not return error after setting the object above
+		if err != nil {
+			panic(err)
+		}
+		obj = childObj
+	}
+
+	leaf := obj.GetOrCreateKey(keys[len(keys)-1])
+	leaf.AppendValue(hocon.NewHoconQuotedLiteral(text))
+	leaf.SetOrigin(origin)
+}
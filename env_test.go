@@ -0,0 +1,84 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMangleEnvName(t *testing.T) {
+	assert.Equal(t, "akka.loglevel", MangleEnvName("akka_loglevel"))
+	assert.Equal(t, "akka.log-dead-letters", MangleEnvName("akka_log__dead__letters"))
+	assert.Equal(t, "my_key.a", MangleEnvName("my___key_a"))
+	assert.Equal(t, "a_.b", MangleEnvName("a____b"))
+}
+
+func TestEnvOverrides(t *testing.T) {
+	overrides, err := EnvOverrides(EnvOptions{Environ: []string{
+		"CONFIG_FORCE_akka_loglevel=DEBUG",
+		"CONFIG_FORCE_akka_log__dead__letters=off",
+		"CONFIG_FORCE_akka_actor_timeout=5s",
+		"HOME=/root",
+	}})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	app, err := ParseString(`akka { loglevel = INFO, version = "1.0", actor.timeout = 1s }`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conf, err := overrides.WithFallback(app)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	loglevel, err := conf.GetString("akka.loglevel")
+	assert.Nil(t, err)
+	assert.Equal(t, "DEBUG", loglevel)
+
+	deadLetters, err := conf.GetBoolean("akka.log-dead-letters")
+	assert.Nil(t, err)
+	assert.False(t, deadLetters)
+
+	version, err := conf.GetString("akka.version")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0", version)
+
+	timeout, err := conf.GetString("akka.actor.timeout")
+	assert.Nil(t, err)
+	assert.Equal(t, "5s", timeout)
+
+	assert.False(t, conf.HasPath("HOME"))
+}
+
+func TestEnvOverrides_KeepsStrings(t *testing.T) {
+	overrides, err := EnvOverrides(EnvOptions{Environ: []string{"CONFIG_FORCE_app_version=1.10"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, `{app={version="1.10"}}`, overrides.Render())
+}
+
+func TestEnvOverrides_CustomPrefixAndMangle(t *testing.T) {
+	conf, err := EnvOverrides(EnvOptions{
+		Prefix: "APP__",
+		Mangle: func(name string) string {
+			if name == "IGNORED" {
+				return ""
+			}
+			return MangleEnvName(name)
+		},
+		Environ: []string{"APP__server_port=8080", "APP__IGNORED=1"},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	port, err := conf.GetInt32("server.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8080), port)
+	assert.False(t, conf.HasPath("IGNORED"))
+}
@@ -0,0 +1,101 @@
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// FilesOptions tunes LoadFilesAsValues.
+type FilesOptions struct {
+	// KeyMapper maps a file or directory name to its key, the name itself if nil.
+	// An empty key skips the entry.
+	KeyMapper func(name string) string
+	// KeepTrailingNewlines keeps the newlines ending the files, which are trimmed by default.
+	KeepTrailingNewlines bool
+}
+
+// LoadFilesAsValues turns a directory tree into a config where every file name is a key
+// and the file content is its value, the way Kubernetes mounts ConfigMaps and secrets.
+// Nested directories become nested objects. Hidden entries, such as the ..data links
+// created by Kubernetes, are skipped. The result is usually used as a fallback layer.
+func LoadFilesAsValues(dir string, opts ...FilesOptions) (*Config, error) {
+	var opt FilesOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	root := hocon.NewHoconObject()
+	if err := loadFilesAsValues(dir, root, opt); err != nil {
+		return nil, err
+	}
+
+	return newConfigFromObject(root)
+}
+
+func loadFilesAsValues(dir string, obj *hocon.HoconObject, opt FilesOptions) error {
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	names, err := dirFile.Readdirnames(-1)
+	dirFile.Close()
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		key := name
+		if opt.KeyMapper != nil {
+			key = opt.KeyMapper(name)
+		}
+		if len(key) == 0 {
+			continue
+		}
+
+		filename := filepath.Join(dir, name)
+		// os.Stat follows the symbolic links Kubernetes uses for mounted files
+		info, err := os.Stat(filename)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			childObj := hocon.NewHoconObject()
+			if err := loadFilesAsValues(filename, childObj, opt); err != nil {
+				return err
+			}
+
+			child := obj.GetOrCreateKey(key)
+			child.AppendValue(childObj)
+			child.SetOrigin(hocon.NewHoconOrigin(filename, 0))
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+
+		text := string(data)
+		if !opt.KeepTrailingNewlines {
+			text = strings.TrimRight(text, "\r\n")
+		}
+
+		child := obj.GetOrCreateKey(key)
+		child.AppendValue(hocon.NewHoconQuotedLiteral(text))
+		child.SetOrigin(hocon.NewHoconOrigin(filename, 0))
+	}
+
+	return nil
+}
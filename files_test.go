@@ -0,0 +1,99 @@
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFilesAsValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go_hocon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "..data", "cache"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "..data"), "db.password", "secret\n")
+	writeTestFile(t, filepath.Join(dir, "..data", "cache"), "SIZE", "10MiB\n")
+	if err := os.Symlink(filepath.Join("..data", "db.password"), filepath.Join(dir, "db.password")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("..data", "cache"), filepath.Join(dir, "cache")); err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err := LoadFilesAsValues(dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	value, err := conf.Root().GetChildObject("db.password")
+	if assert.Nil(t, err) {
+		password, err := value.GetString()
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, filepath.Join(dir, "db.password"), value.Origin().Description())
+	}
+
+	size, err := conf.GetByteSize("cache.SIZE")
+	if assert.Nil(t, err) {
+		assert.Equal(t, int64(10*1024*1024), size.Int64())
+	}
+
+	assert.Equal(t, []string{"cache", "db.password"}, rootKeys(t, conf))
+
+	mapped, err := LoadFilesAsValues(dir, FilesOptions{
+		KeyMapper: func(name string) string {
+			if name == "cache" {
+				return ""
+			}
+			return strings.Replace(strings.ToLower(name), ".", "-", -1)
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	password, err := mapped.GetString("db-password")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", password)
+	assert.False(t, mapped.HasPath("cache"))
+}
+
+func rootKeys(t *testing.T, conf *Config) []string {
+	obj, err := conf.Root().GetObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return obj.GetKeys()
+}
+
+func TestLoadFilesAsValues_KeepsStrings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go_hocon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, dir, "password", "1e5\n")
+	writeTestFile(t, dir, "token", "null")
+
+	conf, err := LoadFilesAsValues(dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, `{password="1e5",token="null"}`, conf.Render())
+
+	unwrapped, err := conf.Unwrapped()
+	if assert.Nil(t, err) {
+		assert.Equal(t, map[string]interface{}{"password": "1e5", "token": "null"}, unwrapped)
+	}
+}
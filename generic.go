@@ -0,0 +1,263 @@
+package configuration
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	bigIntType   = reflect.TypeOf(big.Int{})
+	valueType    = reflect.TypeOf(hocon.HoconValue{})
+)
+
+// Get converts the value at the path to T. Booleans, strings, integers, floats and
+// time.Duration use the conversions of hocon.HoconValue, a *big.Int is read as a byte
+// size and a *hocon.HoconValue is returned as is. Slices are read from lists, maps
+// with string keys from objects, and structs from objects whose keys are given by the
+// hocon tag of each field or else match its name ignoring case. Fields tagged with
+// hocon:"-" or missing from the object are left untouched, pointers are allocated
+// when needed and interface{} gets the unwrapped value.
+func Get[T any](cfg *Config, path string) (T, error) {
+	return getValue(cfg, path, decodeAs[T](path), nil)
+}
+
+// GetOr is like Get but returns def when the config has no value at the path.
+func GetOr[T any](cfg *Config, path string, def T) (T, error) {
+	return getValue(cfg, path, decodeAs[T](path), []T{def})
+}
+
+// Lookup is like Get but tells whether the path is set, a missing path is not an error.
+func Lookup[T any](cfg *Config, path string) (T, bool, error) {
+	return lookupValue(cfg, path, decodeAs[T](path))
+}
+
+// Must is like Get but panics on error.
+func Must[T any](cfg *Config, path string) T {
+	return mustValue(Get[T](cfg, path))
+}
+
+// GetList converts every element of the list at the path to T, see Get.
+func GetList[T any](cfg *Config, path string) ([]T, error) {
+	return Get[[]T](cfg, path)
+}
+
+// decodeAs returns a conversion of the value at the path to T.
+func decodeAs[T any](path string) func(*hocon.HoconValue) (T, error) {
+	return func(value *hocon.HoconValue) (T, error) {
+		var result T
+		if err := decodeValue(value, reflect.ValueOf(&result).Elem()); err != nil {
+			return result, fmt.Errorf("cannot get %s: %s", path, err)
+		}
+		return result, nil
+	}
+}
+
+func decodeValue(value *hocon.HoconValue, out reflect.Value) error {
+	switch out.Type() {
+	case reflect.PtrTo(valueType):
+		out.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.PtrTo(bigIntType):
+		size, err := value.GetByteSize()
+		if err != nil {
+			return err
+		}
+		out.Set(reflect.ValueOf(size))
+		return nil
+	case durationType:
+		if err := checkLeaf(value, out); err != nil {
+			return err
+		}
+
+		duration, err := value.GetTimeDuration(true)
+		if err != nil {
+			return err
+		}
+		out.SetInt(int64(duration))
+		return nil
+	}
+
+	switch out.Kind() {
+	case reflect.Ptr:
+		if out.IsNil() {
+			out.Set(reflect.New(out.Type().Elem()))
+		}
+		return decodeValue(value, out.Elem())
+	case reflect.Interface:
+		if out.NumMethod() > 0 {
+			return fmt.Errorf("unsupported type %s", out.Type())
+		}
+
+		unwrapped, err := value.Unwrapped()
+		if err != nil {
+			return err
+		}
+		if unwrapped != nil {
+			out.Set(reflect.ValueOf(unwrapped))
+		}
+		return nil
+	case reflect.Bool:
+		if err := checkLeaf(value, out); err != nil {
+			return err
+		}
+
+		boolV, err := value.GetBoolean()
+		if err != nil {
+			return err
+		}
+		out.SetBool(boolV)
+		return nil
+	case reflect.String:
+		if err := checkLeaf(value, out); err != nil {
+			return err
+		}
+
+		stringV, err := value.GetString()
+		if err != nil {
+			return err
+		}
+		out.SetString(stringV)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if err := checkLeaf(value, out); err != nil {
+			return err
+		}
+
+		intV, err := value.GetInt64()
+		if err != nil {
+			return err
+		}
+		if out.OverflowInt(intV) {
+			return fmt.Errorf("%d overflows %s", intV, out.Type())
+		}
+		out.SetInt(intV)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if err := checkLeaf(value, out); err != nil {
+			return err
+		}
+
+		intV, err := value.GetInt64()
+		if err != nil {
+			return err
+		}
+		if intV < 0 || out.OverflowUint(uint64(intV)) {
+			return fmt.Errorf("%d overflows %s", intV, out.Type())
+		}
+		out.SetUint(uint64(intV))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if err := checkLeaf(value, out); err != nil {
+			return err
+		}
+
+		floatV, err := value.GetFloat64()
+		if err != nil {
+			return err
+		}
+		if out.OverflowFloat(floatV) {
+			return fmt.Errorf("%g overflows %s", floatV, out.Type())
+		}
+		out.SetFloat(floatV)
+		return nil
+	case reflect.Slice:
+		if !value.IsArray() && !value.IsObject() {
+			return fmt.Errorf("cannot convert %s to %s", value.Render(), out.Type())
+		}
+
+		elements, err := value.GetList()
+		if err != nil {
+			return err
+		}
+
+		slice := reflect.MakeSlice(out.Type(), len(elements), len(elements))
+		for i, element := range elements {
+			if err := decodeValue(element, slice.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %s", i, err)
+			}
+		}
+		out.Set(slice)
+		return nil
+	case reflect.Map:
+		if out.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", out.Type())
+		}
+
+		obj, err := value.GetObject()
+		if err != nil {
+			return err
+		}
+
+		m := reflect.MakeMapWithSize(out.Type(), len(obj.GetKeys()))
+		for _, key := range obj.GetKeys() {
+			element := reflect.New(out.Type().Elem()).Elem()
+			if err := decodeValue(obj.GetKey(key), element); err != nil {
+				return fmt.Errorf("key %s: %s", key, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(out.Type().Key()), element)
+		}
+		out.Set(m)
+		return nil
+	case reflect.Struct:
+		return decodeStruct(value, out)
+	}
+
+	return fmt.Errorf("unsupported type %s", out.Type())
+}
+
+func decodeStruct(value *hocon.HoconValue, out reflect.Value) error {
+	obj, err := value.GetObject()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < out.NumField(); i++ {
+		field := out.Type().Field(i)
+		if len(field.PkgPath) > 0 {
+			// unexported
+			continue
+		}
+
+		key := field.Tag.Get("hocon")
+		if key == "-" {
+			continue
+		}
+
+		var child *hocon.HoconValue
+		if len(key) > 0 {
+			child = obj.GetKey(key)
+		} else {
+			for _, objKey := range obj.GetKeys() {
+				if strings.EqualFold(objKey, field.Name) {
+					key = objKey
+					child = obj.GetKey(objKey)
+				}
+			}
+		}
+
+		if child == nil {
+			continue
+		}
+
+		if err := decodeValue(child, out.Field(i)); err != nil {
+			return fmt.Errorf("key %s: %s", key, err)
+		}
+	}
+
+	return nil
+}
+
+// checkLeaf fails when the value is an object or an array, which the conversions of
+// hocon.HoconValue would read as an empty string.
+func checkLeaf(value *hocon.HoconValue, out reflect.Value) error {
+	if value.IsObject() || value.IsArray() {
+		return fmt.Errorf("cannot convert %s to %s", value.Render(), out.Type())
+	}
+	return nil
+}
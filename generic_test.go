@@ -0,0 +1,192 @@
+package configuration
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/goreflect/go_hocon/hocon"
+	"github.com/stretchr/testify/assert"
+)
+
+const genericConfig = `
+name = demo
+debug = on
+workers = 8
+ratio = 0.75
+timeout = 1500ms
+max-body = 2K
+ports = [80, 443]
+small = 300
+servers {
+  "0" { host = a, port = 80 }
+  "1" { host = b, port = 81, tags = [x] }
+}
+limits { read = 10, write = 20 }
+http {
+  host = localhost
+  port = 8080
+  read-timeout = 5s
+  Secure = true
+  ignored = nope
+  tls { cert = "/etc/cert.pem" }
+}
+`
+
+type genericTLS struct {
+	Cert string
+}
+
+type genericHTTP struct {
+	Host        string
+	Port        uint16
+	ReadTimeout time.Duration `hocon:"read-timeout"`
+	Secure      bool
+	Ignored     string `hocon:"-"`
+	TLS         *genericTLS
+	Missing     string
+	hidden      string
+}
+
+type genericServer struct {
+	Host string
+	Port int
+	Tags []string
+}
+
+func TestGet(t *testing.T) {
+	conf, err := ParseString(genericConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, err := Get[string](conf, "name"); assert.Nil(t, err) {
+		assert.Equal(t, "demo", value)
+	}
+
+	if value, err := Get[bool](conf, "debug"); assert.Nil(t, err) {
+		assert.True(t, value)
+	}
+
+	if value, err := Get[int](conf, "workers"); assert.Nil(t, err) {
+		assert.Equal(t, 8, value)
+	}
+
+	if value, err := Get[float32](conf, "ratio"); assert.Nil(t, err) {
+		assert.Equal(t, float32(0.75), value)
+	}
+
+	if value, err := Get[time.Duration](conf, "timeout"); assert.Nil(t, err) {
+		assert.Equal(t, 1500*time.Millisecond, value)
+	}
+
+	if value, err := Get[*big.Int](conf, "max-body"); assert.Nil(t, err) {
+		assert.Equal(t, big.NewInt(2048), value)
+	}
+
+	if value, err := Get[*hocon.HoconValue](conf, "limits"); assert.Nil(t, err) {
+		assert.True(t, value.IsObject())
+	}
+
+	if value, err := Get[map[string]int](conf, "limits"); assert.Nil(t, err) {
+		assert.Equal(t, map[string]int{"read": 10, "write": 20}, value)
+	}
+
+	if value, err := Get[interface{}](conf, "ports"); assert.Nil(t, err) {
+		assert.Equal(t, []interface{}{int64(80), int64(443)}, value)
+	}
+
+	if value, err := Get[genericHTTP](conf, "http"); assert.Nil(t, err) {
+		assert.Equal(t, genericHTTP{
+			Host:        "localhost",
+			Port:        8080,
+			ReadTimeout: 5 * time.Second,
+			Secure:      true,
+			TLS:         &genericTLS{Cert: "/etc/cert.pem"},
+		}, value)
+	}
+
+	if value, err := Get[*genericHTTP](conf, "http"); assert.Nil(t, err) {
+		assert.Equal(t, "localhost", value.Host)
+	}
+}
+
+func TestGet_Errors(t *testing.T) {
+	conf, err := ParseString(genericConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	_, err = Get[int](conf, "missing")
+	assert.NotNil(t, err)
+
+	_, err = Get[int](conf, "name")
+	assert.NotNil(t, err)
+
+	_, err = Get[int8](conf, "small")
+	assert.NotNil(t, err)
+
+	_, err = Get[uint](conf, "ratio")
+	assert.NotNil(t, err)
+
+	_, err = Get[string](conf, "http")
+	assert.NotNil(t, err)
+
+	_, err = Get[genericHTTP](conf, "name")
+	assert.NotNil(t, err)
+
+	_, err = Get[map[int]string](conf, "limits")
+	assert.NotNil(t, err)
+
+	_, err = Get[chan int](conf, "workers")
+	assert.NotNil(t, err)
+
+	_, err = Get[[]int](conf, "workers")
+	assert.NotNil(t, err)
+
+	_, err = GetList[string](conf, "name")
+	assert.NotNil(t, err)
+}
+
+func TestGetOr(t *testing.T) {
+	conf, err := ParseString(genericConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, err := GetOr(conf, "workers", 1); assert.Nil(t, err) {
+		assert.Equal(t, 8, value)
+	}
+
+	if value, err := GetOr(conf, "missing", 1); assert.Nil(t, err) {
+		assert.Equal(t, 1, value)
+	}
+
+	if value, err := GetOr(conf, "http.missing", 3*time.Second); assert.Nil(t, err) {
+		assert.Equal(t, 3*time.Second, value)
+	}
+
+	_, err = GetOr(conf, "name", 1)
+	assert.NotNil(t, err)
+}
+
+func TestGetList(t *testing.T) {
+	conf, err := ParseString(genericConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, err := GetList[uint16](conf, "ports"); assert.Nil(t, err) {
+		assert.Equal(t, []uint16{80, 443}, value)
+	}
+
+	if value, err := GetList[genericServer](conf, "servers"); assert.Nil(t, err) {
+		assert.Equal(t, []genericServer{
+			{Host: "a", Port: 80},
+			{Host: "b", Port: 81, Tags: []string{"x"}},
+		}, value)
+	}
+
+	_, err = GetList[int](conf, "limits")
+	assert.NotNil(t, err)
+}
@@ -36,3 +36,8 @@ func (p *HoconArray) String() string {
 	}
 	return "[" + strings.Join(sstr, ",") + "]"
 }
+
+// Append adds the value at the end of the array.
+func (p *HoconArray) Append(value *HoconValue) {
+	p.values = append(p.values, value)
+}
@@ -0,0 +1,350 @@
+package hocon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigDocument is a lossless syntax tree of a HOCON text: whitespace, comments and
+// the exact spelling of every key and value are kept, so that Render returns the
+// parsed text byte for byte.
+type ConfigDocument struct {
+	nodes []documentNode
+	// root holds the fields of the document, it is either one of the nodes or, when
+	// the document is wrapped in braces, nested in one of them.
+	root *documentObject
+}
+
+// documentNode is a node of the syntax tree, rendering its raw text.
+type documentNode interface {
+	render(sb *strings.Builder)
+}
+
+// documentObject is an object with its braces, if any, and every token between them.
+type documentObject struct {
+	children []documentNode
+}
+
+// documentField is a key, its separator and its value with the whitespace between them.
+type documentField struct {
+	children []documentNode
+	path     Path
+	value    *documentValue
+}
+
+// documentInclude is an include statement, kept as is.
+type documentInclude struct {
+	children []documentNode
+}
+
+// documentValue is a value made of one or several concatenated parts.
+type documentValue struct {
+	children []documentNode
+}
+
+// documentArray is an array with its brackets and every token between them.
+type documentArray struct {
+	children []documentNode
+}
+
+func (p documentToken) render(sb *strings.Builder) {
+	sb.WriteString(p.text)
+}
+
+func renderDocumentNodes(sb *strings.Builder, nodes []documentNode) {
+	for _, node := range nodes {
+		node.render(sb)
+	}
+}
+
+func (p *documentObject) render(sb *strings.Builder)  { renderDocumentNodes(sb, p.children) }
+func (p *documentField) render(sb *strings.Builder)   { renderDocumentNodes(sb, p.children) }
+func (p *documentInclude) render(sb *strings.Builder) { renderDocumentNodes(sb, p.children) }
+func (p *documentValue) render(sb *strings.Builder)   { renderDocumentNodes(sb, p.children) }
+func (p *documentArray) render(sb *strings.Builder)   { renderDocumentNodes(sb, p.children) }
+
+// ParseDocument parses text into a ConfigDocument. Only the syntax is checked,
+// substitutions and includes are neither resolved nor loaded.
+func ParseDocument(text string) (*ConfigDocument, error) {
+	tokens, err := tokenizeDocument(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &documentParser{tokens: tokens}
+	doc := &ConfigDocument{}
+
+	start := p.pos
+	for !p.eof() && p.peek().isTrivia() && p.peek().tokenType != documentComma {
+		p.pos++
+	}
+
+	if p.eof() || p.peek().tokenType != documentObjectStart {
+		p.pos = start
+		doc.root, err = p.parseObject(false)
+		if err != nil {
+			return nil, err
+		}
+		doc.nodes = []documentNode{doc.root}
+		return doc, nil
+	}
+
+	for _, token := range tokens[start:p.pos] {
+		doc.nodes = append(doc.nodes, token)
+	}
+
+	doc.root, err = p.parseObject(true)
+	if err != nil {
+		return nil, err
+	}
+	doc.nodes = append(doc.nodes, doc.root)
+
+	for !p.eof() {
+		token := p.next()
+		if !token.isTrivia() || token.tokenType == documentComma {
+			return nil, p.unexpected(token)
+		}
+		doc.nodes = append(doc.nodes, token)
+	}
+
+	return doc, nil
+}
+
+// Render returns the text of the document.
+func (p *ConfigDocument) Render() string {
+	var sb strings.Builder
+	renderDocumentNodes(&sb, p.nodes)
+	return sb.String()
+}
+
+func (p *ConfigDocument) String() string {
+	return p.Render()
+}
+
+type documentParser struct {
+	tokens []documentToken
+	pos    int
+}
+
+func (p *documentParser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *documentParser) peek() documentToken {
+	return p.tokens[p.pos]
+}
+
+func (p *documentParser) next() documentToken {
+	token := p.tokens[p.pos]
+	p.pos++
+	return token
+}
+
+func (p *documentParser) unexpected(token documentToken) error {
+	return fmt.Errorf("line %d: unexpected %q", token.line, token.text)
+}
+
+func (p *documentParser) unexpectedEOF(expected string) error {
+	line := 1
+	if len(p.tokens) > 0 {
+		last := p.tokens[len(p.tokens)-1]
+		line = last.line + strings.Count(last.text, "\n")
+	}
+	return fmt.Errorf("line %d: expected %s before the end of the document", line, expected)
+}
+
+// parseObject parses the fields of an object, starting at its opening brace when braced.
+func (p *documentParser) parseObject(braced bool) (*documentObject, error) {
+	obj := &documentObject{}
+	if braced {
+		obj.children = append(obj.children, p.next())
+	}
+
+	for {
+		if p.eof() {
+			if braced {
+				return nil, p.unexpectedEOF("}")
+			}
+			return obj, nil
+		}
+
+		token := p.peek()
+		switch {
+		case token.tokenType == documentObjectEnd && braced:
+			obj.children = append(obj.children, p.next())
+			return obj, nil
+		case token.isTrivia():
+			obj.children = append(obj.children, p.next())
+		case p.isInclude():
+			obj.children = append(obj.children, p.parseInclude())
+		case token.tokenType == documentUnquoted || token.tokenType == documentQuoted:
+			field, err := p.parseField()
+			if err != nil {
+				return nil, err
+			}
+			obj.children = append(obj.children, field)
+		default:
+			return nil, p.unexpected(token)
+		}
+	}
+}
+
+// isInclude checks whether an include statement starts at the current token.
+func (p *documentParser) isInclude() bool {
+	if p.peek().text != includeSpecial || p.pos+2 >= len(p.tokens) {
+		return false
+	}
+
+	if p.tokens[p.pos+1].tokenType != documentWhitespace {
+		return false
+	}
+
+	next := p.tokens[p.pos+2]
+	return next.tokenType == documentQuoted ||
+		(next.tokenType == documentUnquoted && strings.HasSuffix(next.text, "("))
+}
+
+func (p *documentParser) parseInclude() *documentInclude {
+	include := &documentInclude{}
+	for !p.eof() && !p.isEndOfValue(p.peek()) {
+		include.children = append(include.children, p.next())
+	}
+	include.children = p.untakeTrailingWhitespace(include.children)
+	return include
+}
+
+func (p *documentParser) parseField() (*documentField, error) {
+	field := &documentField{}
+	line := p.peek().line
+
+	var key strings.Builder
+	for !p.eof() {
+		token := p.peek()
+		if token.tokenType != documentUnquoted && token.tokenType != documentQuoted &&
+			token.tokenType != documentWhitespace {
+			break
+		}
+		field.children = append(field.children, p.next())
+	}
+	field.children = p.untakeTrailingWhitespace(field.children)
+
+	for _, child := range field.children {
+		child.render(&key)
+	}
+
+	path, err := ParsePath(key.String())
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %s", line, err)
+	}
+	field.path = path
+
+	p.appendWhitespace(&field.children)
+	if p.eof() {
+		return nil, p.unexpectedEOF("a value")
+	}
+
+	if p.peek().tokenType == documentSeparator {
+		field.children = append(field.children, p.next())
+		p.appendWhitespace(&field.children)
+	} else if p.peek().tokenType != documentObjectStart {
+		return nil, p.unexpected(p.peek())
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	field.value = value
+	field.children = append(field.children, value)
+
+	return field, nil
+}
+
+// parseValue parses the parts of a value up to the end of the line, a comment or a
+// separator. The whitespace following the value is left to the caller.
+func (p *documentParser) parseValue() (*documentValue, error) {
+	value := &documentValue{}
+
+	for !p.eof() && !p.isEndOfValue(p.peek()) {
+		switch p.peek().tokenType {
+		case documentObjectStart:
+			obj, err := p.parseObject(true)
+			if err != nil {
+				return nil, err
+			}
+			value.children = append(value.children, obj)
+		case documentArrayStart:
+			arr, err := p.parseArray()
+			if err != nil {
+				return nil, err
+			}
+			value.children = append(value.children, arr)
+		case documentUnquoted, documentQuoted, documentSubstitution, documentWhitespace:
+			value.children = append(value.children, p.next())
+		default:
+			return nil, p.unexpected(p.peek())
+		}
+	}
+
+	value.children = p.untakeTrailingWhitespace(value.children)
+	if len(value.children) == 0 {
+		if p.eof() {
+			return nil, p.unexpectedEOF("a value")
+		}
+		return nil, p.unexpected(p.peek())
+	}
+
+	return value, nil
+}
+
+func (p *documentParser) parseArray() (*documentArray, error) {
+	arr := &documentArray{children: []documentNode{p.next()}}
+
+	for {
+		if p.eof() {
+			return nil, p.unexpectedEOF("]")
+		}
+
+		token := p.peek()
+		switch {
+		case token.tokenType == documentArrayEnd:
+			arr.children = append(arr.children, p.next())
+			return arr, nil
+		case token.isTrivia():
+			arr.children = append(arr.children, p.next())
+		default:
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			arr.children = append(arr.children, value)
+		}
+	}
+}
+
+func (p *documentParser) isEndOfValue(token documentToken) bool {
+	switch token.tokenType {
+	case documentNewline, documentComment, documentComma, documentObjectEnd, documentArrayEnd:
+		return true
+	}
+	return false
+}
+
+func (p *documentParser) appendWhitespace(nodes *[]documentNode) {
+	for !p.eof() && p.peek().tokenType == documentWhitespace {
+		*nodes = append(*nodes, p.next())
+	}
+}
+
+// untakeTrailingWhitespace gives the whitespace ending nodes back to the parser.
+func (p *documentParser) untakeTrailingWhitespace(nodes []documentNode) []documentNode {
+	for len(nodes) > 0 {
+		token, ok := nodes[len(nodes)-1].(documentToken)
+		if !ok || token.tokenType != documentWhitespace {
+			break
+		}
+		nodes = nodes[:len(nodes)-1]
+		p.pos--
+	}
+	return nodes
+}
@@ -0,0 +1,437 @@
+package hocon
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultDocumentIndent = "  "
+
+// HasPath checks whether the document sets the path or a value nested under it.
+func (p *ConfigDocument) HasPath(path string) bool {
+	parsedPath, err := ParsePath(path)
+	if err != nil || len(parsedPath) == 0 {
+		return false
+	}
+
+	return p.root.hasPath(parsedPath)
+}
+
+// SetValue returns a copy of the document where the path holds the value, given as
+// HOCON text. The last field setting the path is edited in place and the other
+// ones are removed. When no field sets the path, a new one is added to the deepest
+// object already holding a prefix of the path, indented like its siblings. Every
+// other line is kept as is and the receiver is never modified.
+func (p *ConfigDocument) SetValue(path string, value string) (*ConfigDocument, error) {
+	parsedPath, err := parseDocumentPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedValue, err := parseDocumentValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := p.copy()
+	if !doc.root.setValue(parsedPath, parsedValue) {
+		doc.root.insertValue(parsedPath, parsedValue, doc.newline(), "")
+	}
+	return doc, nil
+}
+
+// RemoveValue returns a copy of the document without the fields setting the path
+// or values nested under it, each one removed with its line when it is alone on it.
+// The receiver is never modified.
+func (p *ConfigDocument) RemoveValue(path string) (*ConfigDocument, error) {
+	parsedPath, err := parseDocumentPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := p.copy()
+	doc.root.removeValue(parsedPath)
+	return doc, nil
+}
+
+func parseDocumentPath(path string) (Path, error) {
+	parsedPath, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parsedPath) == 0 {
+		return nil, fmt.Errorf("cannot edit the root of a document")
+	}
+	return parsedPath, nil
+}
+
+// parseDocumentValue parses text holding a single value, surrounding whitespace aside.
+func parseDocumentValue(text string) (*documentValue, error) {
+	tokens, err := tokenizeDocument(strings.TrimSpace(text))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+
+	parser := &documentParser{tokens: tokens}
+	value, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.eof() {
+		return nil, fmt.Errorf("invalid value %q: unexpected %q", text, parser.peek().text)
+	}
+	return value, nil
+}
+
+// newline returns the line ending used by the document.
+func (p *ConfigDocument) newline() string {
+	if strings.Contains(p.Render(), "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+func (p *ConfigDocument) copy() *ConfigDocument {
+	doc := &ConfigDocument{nodes: copyDocumentNodes(p.nodes)}
+	for _, node := range doc.nodes {
+		if obj, ok := node.(*documentObject); ok {
+			doc.root = obj
+		}
+	}
+	return doc
+}
+
+func copyDocumentNodes(nodes []documentNode) []documentNode {
+	copied := make([]documentNode, len(nodes))
+	for i, node := range nodes {
+		switch n := node.(type) {
+		case *documentObject:
+			copied[i] = &documentObject{children: copyDocumentNodes(n.children)}
+		case *documentField:
+			children := copyDocumentNodes(n.children)
+			copied[i] = &documentField{
+				children: children,
+				path:     n.path,
+				value:    children[len(children)-1].(*documentValue),
+			}
+		case *documentInclude:
+			copied[i] = &documentInclude{children: copyDocumentNodes(n.children)}
+		case *documentValue:
+			copied[i] = &documentValue{children: copyDocumentNodes(n.children)}
+		case *documentArray:
+			copied[i] = &documentArray{children: copyDocumentNodes(n.children)}
+		default:
+			copied[i] = node
+		}
+	}
+	return copied
+}
+
+// lastObject returns the last object literal the value is made of, nil if none.
+func (p *documentValue) lastObject() *documentObject {
+	for i := len(p.children) - 1; i >= 0; i-- {
+		if obj, ok := p.children[i].(*documentObject); ok {
+			return obj
+		}
+	}
+	return nil
+}
+
+func (p *documentObject) fields() []*documentField {
+	var fields []*documentField
+	for _, child := range p.children {
+		if field, ok := child.(*documentField); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func (p *documentObject) hasPath(path Path) bool {
+	for _, field := range p.fields() {
+		if field.path.HasPrefix(path) {
+			return true
+		}
+
+		if path.HasPrefix(field.path) {
+			for _, child := range field.value.children {
+				if obj, ok := child.(*documentObject); ok && obj.hasPath(path[len(field.path):]) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// setValue sets the value on the last field setting the path, removing the fields
+// it overrides. It returns whether such a field was found.
+func (p *documentObject) setValue(path Path, value *documentValue) bool {
+	found := false
+	fields := p.fields()
+
+	for i := len(fields) - 1; i >= 0; i-- {
+		field := fields[i]
+
+		switch {
+		case field.path.Equal(path):
+			if found {
+				p.removeField(field)
+				continue
+			}
+			field.setValue(value)
+			found = true
+		case field.path.HasPrefix(path):
+			p.removeField(field)
+		case path.HasPrefix(field.path):
+			rest := path[len(field.path):]
+			for _, child := range field.value.children {
+				obj, ok := child.(*documentObject)
+				if !ok {
+					continue
+				}
+
+				if found {
+					obj.removeValue(rest)
+				} else if obj == field.value.lastObject() {
+					found = obj.setValue(rest, value)
+				}
+			}
+		}
+	}
+
+	return found
+}
+
+// insertValue adds a field for the path to the deepest object holding a prefix of it.
+func (p *documentObject) insertValue(path Path, value *documentValue, newline, indent string) {
+	fields := p.fields()
+	for i := len(fields) - 1; i >= 0; i-- {
+		field := fields[i]
+		if len(field.path) >= len(path) || !path.HasPrefix(field.path) {
+			continue
+		}
+
+		if obj := field.value.lastObject(); obj != nil {
+			obj.insertValue(path[len(field.path):], value, newline, p.fieldIndent(indent)+defaultDocumentIndent)
+			return
+		}
+	}
+
+	p.appendField(newDocumentField(path, value), newline, indent)
+}
+
+func newDocumentField(path Path, value *documentValue) *documentField {
+	return &documentField{
+		children: []documentNode{
+			documentToken{tokenType: documentUnquoted, text: path.Render()},
+			documentToken{tokenType: documentWhitespace, text: " "},
+			documentToken{tokenType: documentSeparator, text: "="},
+			documentToken{tokenType: documentWhitespace, text: " "},
+			value,
+		},
+		path:  path,
+		value: value,
+	}
+}
+
+func (p *documentField) setValue(value *documentValue) {
+	last := len(p.children) - 1
+	hasSeparator := false
+	for _, child := range p.children[:last] {
+		if token, ok := child.(documentToken); ok && token.tokenType == documentSeparator {
+			hasSeparator = true
+		}
+	}
+
+	children := p.children[:last:last]
+	if !hasSeparator && value.lastObject() == nil {
+		if token, ok := children[len(children)-1].(documentToken); !ok || token.tokenType != documentWhitespace {
+			children = append(children, documentToken{tokenType: documentWhitespace, text: " "})
+		}
+		children = append(children,
+			documentToken{tokenType: documentSeparator, text: "="},
+			documentToken{tokenType: documentWhitespace, text: " "})
+	}
+
+	p.children = append(children, value)
+	p.value = value
+}
+
+func (p *documentObject) isBraced() bool {
+	if len(p.children) == 0 {
+		return false
+	}
+
+	token, ok := p.children[0].(documentToken)
+	return ok && token.tokenType == documentObjectStart
+}
+
+// fieldIndent returns the indentation of the fields of the object, guessed from the
+// first field starting a line, the given default otherwise.
+func (p *documentObject) fieldIndent(defaultIndent string) string {
+	for i, child := range p.children {
+		if _, ok := child.(*documentField); !ok {
+			continue
+		}
+
+		start, indent := lineStart(p.children, i)
+		if start >= 0 {
+			return indent
+		}
+	}
+	return defaultIndent
+}
+
+// lineStart returns the index of the first node of the line the node at index i is
+// on with its indentation, or -1 when other nodes come before it on the line.
+func lineStart(nodes []documentNode, i int) (int, string) {
+	start := i
+	indent := ""
+	if start > 0 {
+		if token, ok := nodes[start-1].(documentToken); ok && token.tokenType == documentWhitespace {
+			start--
+			indent = token.text
+		}
+	}
+
+	if start == 0 {
+		return start, indent
+	}
+
+	if token, ok := nodes[start-1].(documentToken); ok && token.tokenType == documentNewline {
+		return start, indent
+	}
+	return -1, ""
+}
+
+func (p *documentObject) appendField(field *documentField, newline, indent string) {
+	if !p.isBraced() {
+		var nodes []documentNode
+		if last := len(p.children) - 1; last >= 0 {
+			if token, ok := p.children[last].(documentToken); !ok || token.tokenType != documentNewline {
+				nodes = append(nodes, documentToken{tokenType: documentNewline, text: newline})
+			}
+		}
+
+		if fieldIndent := p.fieldIndent(indent); len(fieldIndent) > 0 {
+			nodes = append(nodes, documentToken{tokenType: documentWhitespace, text: fieldIndent})
+		}
+		nodes = append(nodes, field, documentToken{tokenType: documentNewline, text: newline})
+
+		p.children = append(p.children, nodes...)
+		return
+	}
+
+	end := len(p.children) - 1
+	closingStart, closingIndent := lineStart(p.children, end)
+
+	if closingStart < 0 {
+		// the object ends on a line holding other values, the field goes on it too
+		before := end
+		if token, ok := p.children[end-1].(documentToken); ok && token.tokenType == documentWhitespace {
+			before--
+		}
+
+		nodes := []documentNode{documentToken{tokenType: documentWhitespace, text: " "}, field}
+		if len(p.fields()) > 0 {
+			nodes = append([]documentNode{documentToken{tokenType: documentComma, text: ","}}, nodes...)
+		}
+		if before == end {
+			nodes = append(nodes, documentToken{tokenType: documentWhitespace, text: " "})
+		}
+
+		p.insertChildren(before, nodes...)
+		return
+	}
+
+	nodes := []documentNode{
+		documentToken{tokenType: documentWhitespace, text: p.fieldIndent(closingIndent + defaultDocumentIndent)},
+		field,
+		documentToken{tokenType: documentNewline, text: newline},
+	}
+	p.insertChildren(closingStart, nodes...)
+}
+
+func (p *documentObject) insertChildren(i int, nodes ...documentNode) {
+	children := make([]documentNode, 0, len(p.children)+len(nodes))
+	children = append(children, p.children[:i]...)
+	children = append(children, nodes...)
+	p.children = append(children, p.children[i:]...)
+}
+
+// removeValue removes the fields setting the path or values nested under it.
+func (p *documentObject) removeValue(path Path) {
+	for _, field := range p.fields() {
+		switch {
+		case field.path.HasPrefix(path):
+			p.removeField(field)
+		case path.HasPrefix(field.path):
+			for _, child := range field.value.children {
+				if obj, ok := child.(*documentObject); ok {
+					obj.removeValue(path[len(field.path):])
+				}
+			}
+		}
+	}
+}
+
+// removeField removes the field with the separators and comment following it on its
+// line, and the whole line when nothing else is on it.
+func (p *documentObject) removeField(field *documentField) {
+	index := -1
+	for i, child := range p.children {
+		if child == documentNode(field) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return
+	}
+
+	end := index + 1
+	hasComma := false
+	for ; end < len(p.children); end++ {
+		token, ok := p.children[end].(documentToken)
+		if !ok || (token.tokenType == documentComma && hasComma) ||
+			(token.tokenType != documentWhitespace && token.tokenType != documentComma && token.tokenType != documentComment) {
+			break
+		}
+		hasComma = hasComma || token.tokenType == documentComma
+	}
+
+	endsLine := end == len(p.children)
+	if !endsLine {
+		token, ok := p.children[end].(documentToken)
+		endsLine = ok && token.tokenType == documentNewline
+	}
+
+	start, _ := lineStart(p.children, index)
+	switch {
+	case start >= 0 && endsLine:
+		if end < len(p.children) {
+			end++
+		}
+	case hasComma:
+		// other values follow, they take the place of the field
+		start = index
+	default:
+		// the field ends the line after other values, remove the separator before it
+		start, end = index, index+1
+		for start > 0 {
+			token, ok := p.children[start-1].(documentToken)
+			if !ok || (token.tokenType != documentWhitespace && token.tokenType != documentComma) {
+				break
+			}
+			start--
+		}
+	}
+
+	p.children = append(p.children[:start:start], p.children[end:]...)
+}
@@ -0,0 +1,290 @@
+package hocon
+
+import (
+	"testing"
+)
+
+const documentEditText = `# deployment settings
+app {
+  # bumped by the release tooling
+  version = "1.0.0" # keep quoted
+
+  http {
+    port = 80
+  }
+  tags = [a, b]
+}
+
+// overrides
+app.http.port = 8080
+`
+
+func TestConfigDocument_SetValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		path  string
+		value string
+		want  string
+	}{
+		{
+			name:  "replaces a value keeping the comments",
+			text:  documentEditText,
+			path:  "app.version",
+			value: `"1.1.0"`,
+			want: `# deployment settings
+app {
+  # bumped by the release tooling
+  version = "1.1.0" # keep quoted
+
+  http {
+    port = 80
+  }
+  tags = [a, b]
+}
+
+// overrides
+app.http.port = 8080
+`,
+		},
+		{
+			name:  "edits the last field and removes the other ones",
+			text:  documentEditText,
+			path:  "app.http.port",
+			value: "9090",
+			want: `# deployment settings
+app {
+  # bumped by the release tooling
+  version = "1.0.0" # keep quoted
+
+  http {
+  }
+  tags = [a, b]
+}
+
+// overrides
+app.http.port = 9090
+`,
+		},
+		{
+			name:  "adds a field to the deepest object with the sibling indentation",
+			text:  documentEditText,
+			path:  "app.http.host",
+			value: "localhost",
+			want: `# deployment settings
+app {
+  # bumped by the release tooling
+  version = "1.0.0" # keep quoted
+
+  http {
+    port = 80
+    host = localhost
+  }
+  tags = [a, b]
+}
+
+// overrides
+app.http.port = 8080
+`,
+		},
+		{
+			name:  "adds a field at the end of the root",
+			text:  "a = 1",
+			path:  `b."c.d"`,
+			value: "[1, 2]",
+			want:  "a = 1\nb.\"c.d\" = [1, 2]\n",
+		},
+		{
+			name:  "adds a field to an empty document",
+			text:  "",
+			path:  "a",
+			value: "1",
+			want:  "a = 1\n",
+		},
+		{
+			name:  "adds a field to a single line object",
+			text:  "a { b = 1 }\n",
+			path:  "a.c",
+			value: "2",
+			want:  "a { b = 1, c = 2 }\n",
+		},
+		{
+			name:  "adds a field to an empty object",
+			text:  "a {}\n",
+			path:  "a.b",
+			value: "1",
+			want:  "a { b = 1 }\n",
+		},
+		{
+			name:  "keeps windows line endings",
+			text:  "a {\r\n\tb = 1\r\n}\r\n",
+			path:  "a.c",
+			value: "2",
+			want:  "a {\r\n\tb = 1\r\n\tc = 2\r\n}\r\n",
+		},
+		{
+			name:  "replaces an object with a value",
+			text:  "a {\n  b = 1\n}\n",
+			path:  "a",
+			value: "5",
+			want:  "a = 5\n",
+		},
+		{
+			name:  "removes the nested values it overrides",
+			text:  "a.b.c = 1\na.b = 2\nd = 3\n",
+			path:  "a.b",
+			value: "{ x = 1 }",
+			want:  "a.b = { x = 1 }\nd = 3\n",
+		},
+		{
+			name:  "edits a braced root",
+			text:  "{\n  a = 1\n}\n",
+			path:  "b",
+			value: "true",
+			want:  "{\n  a = 1\n  b = true\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseDocument(tt.text)
+			if err != nil {
+				t.Fatalf("ParseDocument() error = %v", err)
+			}
+
+			got, err := doc.SetValue(tt.path, tt.value)
+			if err != nil {
+				t.Fatalf("SetValue() error = %v", err)
+			}
+
+			if got.Render() != tt.want {
+				t.Errorf("SetValue() = %q, want %q", got.Render(), tt.want)
+			}
+
+			if doc.Render() != tt.text {
+				t.Errorf("SetValue() modified the document: %q", doc.Render())
+			}
+
+			if _, err := Parse(got.Render(), nil); err != nil {
+				t.Errorf("Parse() of the edited document error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigDocument_SetValueErrors(t *testing.T) {
+	doc, err := ParseDocument(documentEditText)
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	for _, tt := range []struct{ path, value string }{
+		{path: "", value: "1"},
+		{path: "a..b", value: "1"},
+		{path: "a", value: ""},
+		{path: "a", value: "1\nb = 2"},
+		{path: "a", value: "[1, 2"},
+	} {
+		if _, err := doc.SetValue(tt.path, tt.value); err == nil {
+			t.Errorf("SetValue(%q, %q) error = nil, want an error", tt.path, tt.value)
+		}
+	}
+}
+
+func TestConfigDocument_RemoveValue(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		path string
+		want string
+	}{
+		{
+			name: "removes every field setting the path with its line",
+			text: documentEditText,
+			path: "app.http.port",
+			want: `# deployment settings
+app {
+  # bumped by the release tooling
+  version = "1.0.0" # keep quoted
+
+  http {
+  }
+  tags = [a, b]
+}
+
+// overrides
+`,
+		},
+		{
+			name: "removes the fields nested under the path",
+			text: "a.b = 1\na { c = 2 }\nd = 3\n",
+			path: "a",
+			want: "d = 3\n",
+		},
+		{
+			name: "removes the first field of a line",
+			text: "a { b = 1, c = 2 }\n",
+			path: "a.b",
+			want: "a { c = 2 }\n",
+		},
+		{
+			name: "removes the last field of a line",
+			text: "a { b = 1, c = 2 }\n",
+			path: "a.c",
+			want: "a { b = 1 }\n",
+		},
+		{
+			name: "keeps the document when the path is missing",
+			text: documentEditText,
+			path: "app.missing",
+			want: documentEditText,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseDocument(tt.text)
+			if err != nil {
+				t.Fatalf("ParseDocument() error = %v", err)
+			}
+
+			got, err := doc.RemoveValue(tt.path)
+			if err != nil {
+				t.Fatalf("RemoveValue() error = %v", err)
+			}
+
+			if got.Render() != tt.want {
+				t.Errorf("RemoveValue() = %q, want %q", got.Render(), tt.want)
+			}
+
+			if doc.Render() != tt.text {
+				t.Errorf("RemoveValue() modified the document: %q", doc.Render())
+			}
+		})
+	}
+}
+
+func TestConfigDocument_HasPath(t *testing.T) {
+	doc, err := ParseDocument(documentEditText)
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "app", want: true},
+		{path: "app.version", want: true},
+		{path: "app.http", want: true},
+		{path: "app.http.port", want: true},
+		{path: "app.tags", want: true},
+		{path: "app.missing", want: false},
+		{path: "app.version.major", want: false},
+		{path: "http", want: false},
+		{path: "", want: false},
+	}
+	for _, tt := range tests {
+		if got := doc.HasPath(tt.path); got != tt.want {
+			t.Errorf("HasPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package hocon
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDocument_RendersTheSameText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{
+			name: "empty document",
+			text: "",
+		},
+		{
+			name: "comments and blank lines",
+			text: "# header\n\n// second comment\na = 1 # trailing\n\n\nb : 2 // other\n",
+		},
+		{
+			name: "windows newlines and odd spacing",
+			text: "a\t=  1\r\nb   {\r\n\tc=x   \r\n}\r\n",
+		},
+		{
+			name: "braced root",
+			text: "  # before\n{\n  a = 1,\n  b = [1, 2,\n    3]\n}\n# after\n",
+		},
+		{
+			name: "dotted, quoted and spaced keys",
+			text: "a.b.c = 1\n\"x.y\" . z = 2\nsome key = 3\n",
+		},
+		{
+			name: "strings and concatenations",
+			text: "a = \"quoted \\\" string\" tail\nb = \"\"\"triple\n\"quoted\" text\"\"\"\"\nc = foo ${bar} \"baz\" ${?qux}\n",
+		},
+		{
+			name: "nested objects and arrays",
+			text: "a { b { c = [ { d = 1 }, [2, 3] ] } }\ne = [\n  # comment in array\n  1\n  2 // two\n]\n",
+		},
+		{
+			name: "includes and plus equals",
+			text: "include \"other.conf\"\ninclude required(file(\"x.conf\"))\npath += /usr/bin\nobj { include \"nested\" }\n",
+		},
+		{
+			name: "quoted urls and unicode",
+			text: "url = \"http://example.com/a?b\"\nname = été hiver\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseDocument(tt.text)
+			if err != nil {
+				t.Fatalf("ParseDocument() error = %v", err)
+			}
+
+			if got := doc.Render(); got != tt.text {
+				t.Errorf("Render() = %q, want %q", got, tt.text)
+			}
+		})
+	}
+}
+
+func TestParseDocument_RendersTheTestFiles(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "tests", "*.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc, err := ParseDocument(string(data))
+		if err != nil {
+			t.Errorf("ParseDocument(%s) error = %v", file, err)
+			continue
+		}
+
+		if got := doc.Render(); got != string(data) {
+			t.Errorf("Render() of %s differs from the file", file)
+		}
+	}
+}
+
+func TestParseDocument_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{
+			name: "unterminated quoted string",
+			text: "a = \"b\n",
+		},
+		{
+			name: "unterminated triple quoted string",
+			text: "a = \"\"\"b\n",
+		},
+		{
+			name: "unterminated substitution",
+			text: "a = ${b\n",
+		},
+		{
+			name: "unterminated object",
+			text: "a {\n b = 1\n",
+		},
+		{
+			name: "unterminated array",
+			text: "a = [1, 2\n",
+		},
+		{
+			name: "missing value",
+			text: "a =\nb = 1\n",
+		},
+		{
+			name: "missing separator",
+			text: "a ]\n",
+		},
+		{
+			name: "invalid key",
+			text: "a..b = 1\n",
+		},
+		{
+			name: "forbidden character",
+			text: "a = b`c\n",
+		},
+		{
+			name: "text after braced root",
+			text: "{ a = 1 }\nb = 2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDocument(tt.text); err == nil {
+				t.Errorf("ParseDocument() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestParseDocument_FieldPaths(t *testing.T) {
+	doc, err := ParseDocument("a.b = 1\n\"c.d\" { e = 2 }\n")
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	var paths []string
+	for _, child := range doc.root.children {
+		if field, ok := child.(*documentField); ok {
+			paths = append(paths, field.path.Render())
+		}
+	}
+
+	want := []string{"a.b", `"c.d"`}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("field paths = %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,187 @@
+package hocon
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type documentTokenType int
+
+const (
+	documentWhitespace documentTokenType = iota
+	documentNewline
+	documentComment
+	documentUnquoted
+	documentQuoted
+	documentSubstitution
+	documentObjectStart
+	documentObjectEnd
+	documentArrayStart
+	documentArrayEnd
+	documentComma
+	documentSeparator
+)
+
+// documentToken is a piece of a document with its raw text, whitespace and comments
+// included, so that the tokens of a text always concatenate back to it.
+type documentToken struct {
+	tokenType documentTokenType
+	text      string
+	line      int
+}
+
+// isTrivia checks whether the token has no meaning besides separating the others.
+func (p documentToken) isTrivia() bool {
+	switch p.tokenType {
+	case documentWhitespace, documentNewline, documentComment, documentComma:
+		return true
+	}
+	return false
+}
+
+// tokenizeDocument splits text into document tokens, without losing a single byte.
+func tokenizeDocument(text string) ([]documentToken, error) {
+	var tokens []documentToken
+	line := 1
+
+	for i := 0; i < len(text); {
+		tokenType, n, err := nextDocumentToken(text[i:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+
+		token := documentToken{tokenType: tokenType, text: text[i : i+n], line: line}
+		tokens = append(tokens, token)
+		line += strings.Count(token.text, "\n")
+		i += n
+	}
+
+	return tokens, nil
+}
+
+// nextDocumentToken returns the type and the length of the token text starts with.
+func nextDocumentToken(text string) (documentTokenType, int, error) {
+	switch {
+	case text[0] == '\n':
+		return documentNewline, 1, nil
+	case text[0] == '#' || strings.HasPrefix(text, "//"):
+		end := strings.IndexByte(text, '\n')
+		if end < 0 {
+			end = len(text)
+		}
+		return documentComment, end, nil
+	case text[0] == '{':
+		return documentObjectStart, 1, nil
+	case text[0] == '}':
+		return documentObjectEnd, 1, nil
+	case text[0] == '[':
+		return documentArrayStart, 1, nil
+	case text[0] == ']':
+		return documentArrayEnd, 1, nil
+	case text[0] == ',':
+		return documentComma, 1, nil
+	case text[0] == '=' || text[0] == ':':
+		return documentSeparator, 1, nil
+	case strings.HasPrefix(text, plusAssignmentToken):
+		return documentSeparator, len(plusAssignmentToken), nil
+	case strings.HasPrefix(text, startOfTripleQuotedTextToken):
+		n, err := tripleQuotedLength(text)
+		return documentQuoted, n, err
+	case text[0] == '"':
+		n, err := quotedLength(text)
+		return documentQuoted, n, err
+	case strings.HasPrefix(text, "${"):
+		n, err := substitutionLength(text)
+		return documentSubstitution, n, err
+	}
+
+	if n := whitespaceLength(text); n > 0 {
+		return documentWhitespace, n, nil
+	}
+
+	if n := unquotedLength(text); n > 0 {
+		return documentUnquoted, n, nil
+	}
+
+	r, _ := utf8.DecodeRuneInString(text)
+	return 0, 0, fmt.Errorf("unexpected character %q", r)
+}
+
+func isDocumentWhitespace(r rune) bool {
+	return r != '\n' && (unicode.IsSpace(r) || r == '\ufeff')
+}
+
+func whitespaceLength(text string) int {
+	n := 0
+	for n < len(text) {
+		r, size := utf8.DecodeRuneInString(text[n:])
+		if !isDocumentWhitespace(r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+func unquotedLength(text string) int {
+	n := 0
+	for n < len(text) {
+		r, size := utf8.DecodeRuneInString(text[n:])
+		if isDocumentWhitespace(r) || r == '\n' ||
+			strings.ContainsRune(HoconNotInUnquotedText, r) ||
+			strings.HasPrefix(text[n:], "//") {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+func quotedLength(text string) (int, error) {
+	for n := 1; n < len(text); n++ {
+		switch text[n] {
+		case '\\':
+			n++
+		case '"':
+			return n + 1, nil
+		case '\n':
+			return 0, fmt.Errorf("unterminated quoted string")
+		}
+	}
+	return 0, fmt.Errorf("unterminated quoted string")
+}
+
+func tripleQuotedLength(text string) (int, error) {
+	start := len(startOfTripleQuotedTextToken)
+	end := strings.Index(text[start:], endOfTripleQuotedTextToken)
+	if end < 0 {
+		return 0, fmt.Errorf("unterminated triple quoted string")
+	}
+
+	// quotes right before the closing ones belong to the string
+	n := start + end + len(endOfTripleQuotedTextToken)
+	for n < len(text) && text[n] == '"' {
+		n++
+	}
+	return n, nil
+}
+
+func substitutionLength(text string) (int, error) {
+	for n := 2; n < len(text); n++ {
+		switch text[n] {
+		case '"':
+			length, err := quotedLength(text[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += length - 1
+		case '}':
+			return n + 1, nil
+		case '\n':
+			return 0, fmt.Errorf("unterminated substitution")
+		}
+	}
+	return 0, fmt.Errorf("unterminated substitution")
+}
@@ -0,0 +1,142 @@
+package hocon
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// WithPathValue returns a copy of p where the path holds the given value. Objects
+// are created along the path when missing, replacing any other value, and array
+// elements may be addressed by index. Only the values along the path are copied,
+// everything else is shared and p is never modified.
+func (p *HoconValue) WithPathValue(path Path, value *HoconValue) (*HoconValue, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	key := path[0]
+
+	if !p.IsObject() && p.IsArray() {
+		elements, index, err := p.copyArrayForIndex(key)
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := elements[index].WithPathValue(path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		elements[index] = child
+
+		return p.wrapCopy(&HoconArray{values: elements}), nil
+	}
+
+	obj := NewHoconObject()
+	if p.IsObject() {
+		current, err := p.GetObject()
+		if err != nil {
+			return nil, err
+		}
+		obj = current.Copy()
+	}
+
+	child, err := obj.GetKey(key).WithPathValue(path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	obj.setKey(key, child)
+
+	return p.wrapCopy(obj), nil
+}
+
+// WithoutPath returns a copy of p where the path is removed, or p itself when the
+// path cannot be found. Only the values along the path are copied.
+func (p *HoconValue) WithoutPath(path Path) (*HoconValue, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the root value")
+	}
+
+	key := path[0]
+
+	if !p.IsObject() && p.IsArray() {
+		elements, index, err := p.copyArrayForIndex(key)
+		if err != nil {
+			return p, nil
+		}
+
+		if len(path) == 1 {
+			elements = append(elements[:index], elements[index+1:]...)
+		} else {
+			child, err := elements[index].WithoutPath(path[1:])
+			if err != nil {
+				return nil, err
+			}
+			if child == elements[index] {
+				return p, nil
+			}
+			elements[index] = child
+		}
+
+		return p.wrapCopy(&HoconArray{values: elements}), nil
+	}
+
+	if !p.IsObject() {
+		return p, nil
+	}
+
+	current, err := p.GetObject()
+	if err != nil {
+		return nil, err
+	}
+
+	child := current.GetKey(key)
+	if child == nil {
+		return p, nil
+	}
+
+	obj := current.Copy()
+	if len(path) == 1 {
+		obj.removeKey(key)
+		return p.wrapCopy(obj), nil
+	}
+
+	newChild, err := child.WithoutPath(path[1:])
+	if err != nil {
+		return nil, err
+	}
+	if newChild == child {
+		return p, nil
+	}
+	obj.setKey(key, newChild)
+
+	return p.wrapCopy(obj), nil
+}
+
+// copyArrayForIndex copies the elements of the array p and checks the key is one of its indices.
+func (p *HoconValue) copyArrayForIndex(key string) ([]*HoconValue, int, error) {
+	arrayV, err := p.GetArray()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	index, err := strconv.Atoi(key)
+	if err != nil || index < 0 || index >= len(arrayV) {
+		return nil, 0, fmt.Errorf("invalid index %s for an array of %d elements", key, len(arrayV))
+	}
+
+	elements := make([]*HoconValue, len(arrayV))
+	copy(elements, arrayV)
+	return elements, index, nil
+}
+
+// wrapCopy wraps the element in a new value with the origin of p.
+func (p *HoconValue) wrapCopy(element HoconElement) *HoconValue {
+	return &HoconValue{values: []HoconElement{element}, origin: p.Origin(), comments: p.Comments()}
+}
+
+// withComments returns a copy of p sharing its elements, documented by the comments.
+func (p *HoconValue) withComments(comments []string) *HoconValue {
+	copied := *p
+	copied.comments = comments
+	return &copied
+}
@@ -0,0 +1,130 @@
+package hocon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHoconValue_WithPathValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		path    Path
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "replaces an existing value",
+			text: "a { b = 1, c = 2 }",
+			path: PathOf("a", "b"),
+			want: "{a:{b:xc:2}}",
+		},
+		{
+			name: "creates missing objects",
+			text: "a = 1",
+			path: PathOf("b", "c"),
+			want: "{a:1b:{c:x}}",
+		},
+		{
+			name: "replaces a leaf on the way by an object",
+			text: "a = 1",
+			path: PathOf("a", "b"),
+			want: "{a:{b:x}}",
+		},
+		{
+			name: "replaces an array element",
+			text: "a = [1, 2]",
+			path: PathOf("a", "1"),
+			want: "{a:[1,x]}",
+		},
+		{
+			name:    "fails on an index out of range",
+			text:    "a = [1, 2]",
+			path:    PathOf("a", "2"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := Parse(tt.text, nil)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			before := compact(root.Value().String())
+
+			got, err := root.Value().WithPathValue(tt.path, wrapInValue(NewHoconLiteral("x")))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithPathValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if after := compact(root.Value().String()); after != before {
+				t.Errorf("WithPathValue() modified the receiver: %v, want %v", after, before)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := compact(got.String()); s != tt.want {
+				t.Errorf("WithPathValue() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoconValue_WithoutPath(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		path Path
+		want string
+	}{
+		{
+			name: "removes a key",
+			text: "a { b = 1, c = 2 }",
+			path: PathOf("a", "b"),
+			want: "{a:{c:2}}",
+		},
+		{
+			name: "removes an array element",
+			text: "a = [1, 2, 3]",
+			path: PathOf("a", "1"),
+			want: "{a:[1,3]}",
+		},
+		{
+			name: "ignores a missing path",
+			text: "a { b = 1 }",
+			path: PathOf("a", "c", "d"),
+			want: "{a:{b:1}}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := Parse(tt.text, nil)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			before := compact(root.Value().String())
+
+			got, err := root.Value().WithoutPath(tt.path)
+			if err != nil {
+				t.Fatalf("WithoutPath() error = %v", err)
+			}
+			if after := compact(root.Value().String()); after != before {
+				t.Errorf("WithoutPath() modified the receiver: %v, want %v", after, before)
+			}
+			if s := compact(got.String()); s != tt.want {
+				t.Errorf("WithoutPath() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+// compact removes the whitespace of a rendered value to ease comparisons, note that
+// object members are not separated by commas
+func compact(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,144 @@
+package hocon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+)
+
+// Equal checks whether two values hold the same content, ignoring the order of the
+// keys, origins, comments and formatting. Numbers are compared by value, so that 100,
+// 1e2, 100.0 and "100" are equal, and the other leaves by their rendered text, so
+// that a and "a" are equal while null and "null" are not.
+// Substitutions which are not resolved are equal when they refer to the same path.
+func (p *HoconValue) Equal(other *HoconValue) bool {
+	if p == other {
+		return true
+	}
+
+	pSub, otherSub := p.unresolvedSubstitution(), other.unresolvedSubstitution()
+	if pSub != nil || otherSub != nil {
+		return pSub != nil && otherSub != nil &&
+			pSub.OriginalPath == otherSub.OriginalPath && pSub.IsOptional == otherSub.IsOptional
+	}
+
+	if p.IsObject() || other.IsObject() {
+		if !p.IsObject() || !other.IsObject() {
+			return false
+		}
+
+		pObj, err := p.GetObject()
+		// must not return error after checking p.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		otherObj, err := other.GetObject()
+		// must not return error after checking other.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		return pObj.Equal(otherObj)
+	}
+
+	if p.IsArray() || other.IsArray() {
+		if !p.IsArray() || !other.IsArray() {
+			return false
+		}
+
+		pArray, err := p.GetArray()
+		// must not return error after checking p.IsArray()
+		if err != nil {
+			panic(err)
+		}
+
+		otherArray, err := other.GetArray()
+		// must not return error after checking other.IsArray()
+		if err != nil {
+			panic(err)
+		}
+
+		if len(pArray) != len(otherArray) {
+			return false
+		}
+
+		for i := range pArray {
+			if !pArray[i].Equal(otherArray[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	pLeaf, pErr := p.Unwrapped()
+	otherLeaf, otherErr := other.Unwrapped()
+	if pErr != nil || otherErr != nil {
+		return false
+	}
+
+	return canonicalLeaf(pLeaf) == canonicalLeaf(otherLeaf)
+}
+
+// canonicalLeaf renders a value unwrapped from a leaf like renderLeaf does, but whole
+// numbers as integers and the other ones as float64. A quoted number or boolean is
+// rendered as such, as the getters read it the same way.
+func canonicalLeaf(leaf interface{}) string {
+	switch v := leaf.(type) {
+	case string:
+		switch typed := typedText(v).(type) {
+		case bool, int64, json.Number, float64:
+			return canonicalLeaf(typed)
+		}
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			intV, _ := big.NewFloat(v).Int(nil)
+			return intV.String()
+		}
+	case json.Number:
+		if intV, ok := new(big.Int).SetString(string(v), 10); ok {
+			return intV.String()
+		}
+	}
+
+	return renderLeaf(leaf)
+}
+
+// Equal checks whether two objects hold the same keys with equal values, in any order.
+func (p *HoconObject) Equal(other *HoconObject) bool {
+	if p == other {
+		return true
+	}
+
+	if p == nil || other == nil || len(p.keys) != len(other.keys) {
+		return false
+	}
+
+	for _, key := range p.keys {
+		otherValue, exist := other.items[key]
+		if !exist || !p.items[key].Equal(otherValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// CanonicalText renders the value as concise HOCON with sorted keys and numbers
+// written by value, so that equal values give the same text.
+func (p *HoconValue) CanonicalText() string {
+	r := &renderer{
+		opt:       RenderOptions{SortKeys: true, Indent: defaultRenderIndent, Newline: defaultRenderNewline},
+		canonical: true,
+	}
+	r.renderValue(p, 0)
+	return r.sb.String()
+}
+
+// CanonicalHash returns the hex encoded SHA-256 of CanonicalText, a stable key for
+// the content of the value.
+func (p *HoconValue) CanonicalHash() string {
+	sum := sha256.Sum256([]byte(p.CanonicalText()))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,150 @@
+package hocon
+
+import (
+	"testing"
+)
+
+func TestHoconValue_Equal(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			name: "key order and formatting are ignored",
+			a:    "a = 1\nb { c = [1, 2], d = x }",
+			b:    `{"b": {"d": "x", "c": [1, 2]}, "a": 1}`,
+			want: true,
+		},
+		{
+			name: "resolved substitutions compare their values",
+			a:    "a = 1\nb = ${a}",
+			b:    "a = 1\nb = 1",
+			want: true,
+		},
+		{
+			name: "different values",
+			a:    "a = 1",
+			b:    "a = 2",
+			want: false,
+		},
+		{
+			name: "quoted numbers and booleans read as such",
+			a:    "a = [1, 1e2, true]",
+			b:    `a = ["1", "100", "true"]`,
+			want: true,
+		},
+		{
+			name: "quoted null is a string",
+			a:    "a = null",
+			b:    `a = "null"`,
+			want: false,
+		},
+		{
+			name: "leading zeros are not a number",
+			a:    "a = 7",
+			b:    `a = "007"`,
+			want: false,
+		},
+		{
+			name: "numbers are compared by value",
+			a:    "a = [1, 100, 1.5, 1e21, -0.0]",
+			b:    "a = [1.0, 1e2, 15e-1, 1000000000000000000000, 0]",
+			want: true,
+		},
+		{
+			name: "large integers keep their digits",
+			a:    "a = 99999999999999999999",
+			b:    "a = 1e20",
+			want: false,
+		},
+		{
+			name: "fractions differ from integers",
+			a:    "a = 1",
+			b:    "a = 1.5",
+			want: false,
+		},
+		{
+			name: "missing key",
+			a:    "a = 1, b = 2",
+			b:    "a = 1",
+			want: false,
+		},
+		{
+			name: "array order matters",
+			a:    "a = [1, 2]",
+			b:    "a = [2, 1]",
+			want: false,
+		},
+		{
+			name: "object differs from leaf",
+			a:    "a { b = 1 }",
+			b:    "a = 1",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Parse(tt.a, nil)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			b, err := ParseWithOptions(tt.b, nil, ParseOptions{Origin: "other.conf"})
+			if err != nil {
+				t.Fatalf("ParseWithOptions() error = %v", err)
+			}
+
+			if got := a.Value().Equal(b.Value()); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+
+			if got := b.Value().Equal(a.Value()); got != tt.want {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.want)
+			}
+
+			if got := a.Value().CanonicalHash() == b.Value().CanonicalHash(); got != tt.want {
+				t.Errorf("CanonicalHash() equality = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoconValue_EqualUnresolved(t *testing.T) {
+	a, err := ParseUnresolved("a = ${b}", nil)
+	if err != nil {
+		t.Fatalf("ParseUnresolved() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		text string
+		want bool
+	}{
+		{text: "a = ${b}", want: true},
+		{text: "a = ${?b}", want: false},
+		{text: "a = ${c}", want: false},
+		{text: `a = "${b}"`, want: false},
+	} {
+		b, err := ParseUnresolved(tt.text, nil)
+		if err != nil {
+			t.Fatalf("ParseUnresolved() error = %v", err)
+		}
+
+		if got := a.Value().Equal(b.Value()); got != tt.want {
+			t.Errorf("Equal(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHoconValue_CanonicalText(t *testing.T) {
+	root, err := Parse("b { d = 1, c = 2.0 }\na = [x, { z = 1e2, y = 0.5 }]", nil)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := `{a=["x",{y=0.5,z=100}],b={c=2,d=1}}`
+	if got := root.Value().CanonicalText(); got != want {
+		t.Errorf("CanonicalText() = %q, want %q", got, want)
+	}
+}
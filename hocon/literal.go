@@ -3,13 +3,25 @@ package hocon
 import "errors"
 
 type HoconLiteral struct {
-	value string
+	value  string
+	quoted bool
 }
 
 func NewHoconLiteral(value string) *HoconLiteral {
 	return &HoconLiteral{value: value}
 }
 
+// NewHoconQuotedLiteral creates a literal which was written as a quoted string, so
+// its text is always a string and never a number, a boolean or null.
+func NewHoconQuotedLiteral(value string) *HoconLiteral {
+	return &HoconLiteral{value: value, quoted: true}
+}
+
+// IsQuoted checks whether the literal was written as a quoted string.
+func (p *HoconLiteral) IsQuoted() bool {
+	return p.quoted
+}
+
 func (p *HoconLiteral) IsString() bool {
 	return true
 }
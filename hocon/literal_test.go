@@ -279,3 +279,49 @@ func TestNewHoconLiteral(t *testing.T) {
 		})
 	}
 }
+
+func TestHoconLiteral_IsQuoted(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{
+			name: "unquoted text is not quoted",
+			text: "a = true",
+			want: false,
+		},
+		{
+			name: "quoted text is quoted",
+			text: `a = "true"`,
+			want: true,
+		},
+		{
+			name: "triple quoted text is quoted",
+			text: `a = """true"""`,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := Parse(tt.text, nil)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			obj, err := root.Value().GetObject()
+			if err != nil {
+				t.Fatalf("GetObject() error = %v", err)
+			}
+
+			literal, ok := obj.GetKey("a").values[0].(*HoconLiteral)
+			if !ok {
+				t.Fatalf("value of a is not a literal")
+			}
+
+			if got := literal.IsQuoted(); got != tt.want {
+				t.Errorf("IsQuoted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -34,33 +34,17 @@ func (p *HoconObject) GetKeys() []string {
 	return p.keys
 }
 
+// Unwrapped returns the object as a map of plain go values, see HoconValue.Unwrapped.
 func (p *HoconObject) Unwrapped() (map[string]interface{}, error) {
-	if p.items == nil || len(p.items) == 0 {
-		return nil, fmt.Errorf("empty HoconObject cannot be unwrapped")
-	}
-
-	dict := map[string]interface{}{}
+	dict := make(map[string]interface{}, len(p.keys))
 
 	for _, k := range p.keys {
-		v := p.items[k]
-
-		if v.IsObject() {
-			obj, err1 := v.GetObject()
-			// must not return error after checking v.IsObject()
-			if err1 != nil {
-				panic(err1)
-			}
-
-			unwrapped, err2 := obj.Unwrapped()
-			// must not return error after checking v.IsObject()
-			if err2 != nil {
-				panic(err1)
-			}
-
-			dict[k] = unwrapped
-		} else {
-			dict[k] = v
+		unwrapped, err := p.items[k].Unwrapped()
+		if err != nil {
+			return nil, fmt.Errorf("cannot unwrap %s: %s", quoteKeyIfNeeded(k), err)
 		}
+
+		dict[k] = unwrapped
 	}
 
 	return dict, nil
@@ -92,6 +76,48 @@ func (p *HoconObject) GetOrCreateKey(key string) *HoconValue {
 	return child
 }
 
+// Copy returns a new object with the same keys, sharing the values of p.
+func (p *HoconObject) Copy() *HoconObject {
+	obj := &HoconObject{
+		items: make(map[string]*HoconValue, len(p.items)),
+		keys:  make([]string, len(p.keys)),
+	}
+
+	for k, v := range p.items {
+		obj.items[k] = v
+	}
+	copy(obj.keys, p.keys)
+
+	return obj
+}
+
+// setKey stores the value under the key, keeping the position of an existing key.
+func (p *HoconObject) setKey(key string, value *HoconValue) {
+	if p.items == nil {
+		p.items = map[string]*HoconValue{}
+	}
+
+	if _, exist := p.items[key]; !exist {
+		p.keys = append(p.keys, key)
+	}
+	p.items[key] = value
+}
+
+func (p *HoconObject) removeKey(key string) {
+	if _, exist := p.items[key]; !exist {
+		return
+	}
+
+	delete(p.items, key)
+	keys := make([]string, 0, len(p.keys))
+	for _, k := range p.keys {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	p.keys = keys
+}
+
 func (p *HoconObject) IsString() bool {
 	return false
 }
@@ -105,7 +131,7 @@ func (p *HoconObject) ToString(indent int) string {
 	buf := bytes.NewBuffer(nil)
 	for _, k := range p.keys {
 		key := quoteStringIfNeeded(k)
-		v := p.items[key]
+		v := p.items[k]
 
 		str := v.ToString(indent)
 		buf.WriteString(fmt.Sprintf("%s%s : %s%s", tmp, key, str, newLine))
@@ -152,16 +178,52 @@ func (p *HoconObject) Merge(other *HoconObject) {
 	}
 }
 
+// MergeImmutable returns a new object holding the keys of p, completed by the keys
+// of other which p does not define. Objects defined by both are merged recursively.
+// Neither p nor other is modified: only the merged objects are copied, the other
+// values are shared.
 func (p *HoconObject) MergeImmutable(other *HoconObject) *HoconObject {
-	thisValues := p.items
-	thisKeys := p.keys
-	newObject := HoconObject{items: thisValues, keys: thisKeys}
+	newObject := p.Copy()
 
 	if other == nil {
-		return &newObject
+		return newObject
 	}
 
-	newObject.Merge(other)
+	for _, otherKey := range other.keys {
+		otherValue := other.items[otherKey]
+
+		thisValue, exist := newObject.items[otherKey]
+		if !exist {
+			newObject.setKey(otherKey, otherValue)
+			continue
+		}
+
+		if !thisValue.IsObject() || !otherValue.IsObject() {
+			// an override keeps documenting the value with the comments of the default
+			if len(thisValue.Comments()) == 0 && len(otherValue.Comments()) > 0 {
+				newObject.items[otherKey] = thisValue.withComments(otherValue.Comments())
+			}
+			continue
+		}
+
+		thisValueObject, err := thisValue.GetObject()
+		// must not return error after checking thisValue.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		otherObjectValue, err := otherValue.GetObject()
+		// must not return error after checking otherValue.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		merged := thisValue.wrapCopy(thisValueObject.MergeImmutable(otherObjectValue))
+		if len(merged.comments) == 0 {
+			merged.comments = otherValue.Comments()
+		}
+		newObject.items[otherKey] = merged
+	}
 
-	return &newObject
+	return newObject
 }
@@ -478,6 +478,29 @@ func TestHoconObject_MergeImmutable(t *testing.T) {
 	}
 }
 
+func TestHoconObject_MergeImmutable_KeepsOperands(t *testing.T) {
+	this := wrapInObject(simpleKey1, makeHoconObject([]string{simpleKey2}, []string{simpleValue2}))
+	other := wrapInObject(simpleKey1, makeHoconObject([]string{simpleKey3}, []string{simpleValue3}))
+	thisBefore, otherBefore := this.String(), other.String()
+
+	got := this.MergeImmutable(other)
+
+	if this.String() != thisBefore {
+		t.Errorf("MergeImmutable() modified the receiver: %v, want %v", this.String(), thisBefore)
+	}
+	if other.String() != otherBefore {
+		t.Errorf("MergeImmutable() modified the argument: %v, want %v", other.String(), otherBefore)
+	}
+
+	merged, err := got.GetKey(simpleKey1).GetObject()
+	if err != nil {
+		t.Fatalf("GetObject() error = %v", err)
+	}
+	if keys := merged.GetKeys(); !reflect.DeepEqual(keys, []string{simpleKey2, simpleKey3}) {
+		t.Errorf("MergeImmutable() keys = %v, want %v", keys, []string{simpleKey2, simpleKey3})
+	}
+}
+
 func TestHoconObject_String(t *testing.T) {
 	type fields struct {
 		items map[string]*HoconValue
@@ -624,9 +647,9 @@ func TestHoconObject_Unwrapped(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "empty object cannot be unwrapped",
-			fields:  fields{},
-			wantErr: true,
+			name:   "empty object returns an empty map",
+			fields: fields{},
+			want:   map[string]interface{}{},
 		},
 		{
 			name: "returns its items",
@@ -635,8 +658,8 @@ func TestHoconObject_Unwrapped(t *testing.T) {
 				keys:  getArrayOfTwoSimpleKeys(),
 			},
 			want: map[string]interface{}{
-				simpleKey1: wrapInValue(simpleLiteral1),
-				simpleKey2: wrapInValue(simpleLiteral2),
+				simpleKey1: simpleValue1,
+				simpleKey2: simpleValue2,
 			},
 		},
 		{
@@ -648,8 +671,18 @@ func TestHoconObject_Unwrapped(t *testing.T) {
 				keys: []string{simpleKey1},
 			},
 			want: map[string]interface{}{
-				simpleKey1: map[string]interface{}{simpleKey2: wrapInValue(simpleLiteral2)},
+				simpleKey1: map[string]interface{}{simpleKey2: simpleValue2},
+			},
+		},
+		{
+			name: "fails with a cycled substitution",
+			fields: fields{
+				items: map[string]*HoconValue{
+					simpleKey1: wrapInValue(getCycledSubstitution()),
+				},
+				keys: []string{simpleKey1},
 			},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,40 @@
+package hocon
+
+import "fmt"
+
+// HoconOrigin tells where a value was defined.
+type HoconOrigin struct {
+	description string
+	line        int
+}
+
+// NewHoconOrigin creates an origin out of a description, e.g. a file name, and a
+// line number, 0 if unknown.
+func NewHoconOrigin(description string, line int) *HoconOrigin {
+	return &HoconOrigin{description: description, line: line}
+}
+
+func (p *HoconOrigin) Description() string {
+	if p == nil {
+		return ""
+	}
+	return p.description
+}
+
+func (p *HoconOrigin) Line() int {
+	if p == nil {
+		return 0
+	}
+	return p.line
+}
+
+func (p *HoconOrigin) String() string {
+	if p == nil {
+		return ""
+	}
+
+	if p.line > 0 {
+		return fmt.Sprintf("%s: %d", p.description, p.line)
+	}
+	return p.description
+}
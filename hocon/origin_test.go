@@ -0,0 +1,64 @@
+package hocon
+
+import "testing"
+
+func TestHoconOrigin_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin *HoconOrigin
+		want   string
+	}{
+		{
+			name:   "renders description and line",
+			origin: NewHoconOrigin("app.conf", 3),
+			want:   "app.conf: 3",
+		},
+		{
+			name:   "renders description without unknown line",
+			origin: NewHoconOrigin("env variables", 0),
+			want:   "env variables",
+		},
+		{
+			name: "renders nil origin as empty",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.origin.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions_RecordsOrigin(t *testing.T) {
+	root, err := ParseWithOptions("a {\n  b = 1\n}\n\nc.d = 2\n", nil, ParseOptions{Origin: "app.conf"})
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "a", want: "app.conf: 1"},
+		{path: "a.b", want: "app.conf: 2"},
+		{path: "c.d", want: "app.conf: 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := ParsePath(tt.path)
+			if err != nil {
+				t.Fatalf("ParsePath() error = %v", err)
+			}
+			node, err := root.Value().GetNode(path)
+			if err != nil {
+				t.Fatalf("GetNode() error = %v", err)
+			}
+			if got := node.Origin().String(); got != tt.want {
+				t.Errorf("Origin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,63 +4,182 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"strconv"
 )
 
 type IncludeCallback func(filename string) (*HoconRoot, error)
 
+// ParseOptions tunes ParseWithOptions.
+type ParseOptions struct {
+	// Origin describes where the text comes from, e.g. its file name.
+	Origin string
+	// Unresolved leaves the substitutions unresolved, see ParseUnresolved.
+	Unresolved bool
+}
+
 type Parser struct {
 	reader   *HoconTokenizer
 	root     *HoconValue
 	callback IncludeCallback
+	origin   string
+	// comments document the key being parsed, until its value is reached
+	comments []string
 
 	substitutions []*HoconSubstitution
 }
 
 func Parse(text string, callback IncludeCallback) (*HoconRoot, error) {
-	return new(Parser).parseText(text, callback)
+	return ParseWithOptions(text, callback, ParseOptions{})
 }
 
-func (p *Parser) parseText(text string, callback IncludeCallback) (*HoconRoot, error) {
-	p.callback = callback
-	p.root = NewHoconValue()
-	p.reader = NewHoconTokenizer(text)
-	p.reader.PullWhitespaceAndComments()
+// ParseUnresolved parses text like Parse does but leaves the substitutions unresolved,
+// so that they can be resolved later against a merged tree with ResolveSubstitutions.
+func ParseUnresolved(text string, callback IncludeCallback) (*HoconRoot, error) {
+	return ParseWithOptions(text, callback, ParseOptions{Unresolved: true})
+}
 
-	if err := p.parseObject(p.root, true, ""); err != nil {
-		return nil, err
+// ParseWithOptions parses text like Parse does, recording the origin of every value.
+func ParseWithOptions(text string, callback IncludeCallback, opts ParseOptions) (*HoconRoot, error) {
+	parser := &Parser{origin: opts.Origin}
+	return parser.parseText(text, callback, !opts.Unresolved)
+}
+
+// ResolveSubstitutions resolves every substitution against the root value. Paths that
+// cannot be found fall back to the environment variable of the same name.
+func ResolveSubstitutions(root *HoconValue, substitutions []*HoconSubstitution) error {
+	for _, sub := range substitutions {
+		if err := resolveSubstitution(root, sub); err != nil {
+			return err
+		}
 	}
 
-	root := NewHoconRoot(p.root)
+	return nil
+}
+
+// ResolveSelfReferences resolves the substitutions of value which refer to the path
+// holding them, or to one of its parents, e.g. path = ${path}":/x". When value sets
+// the path before, they get that earlier setting like Parse does. Otherwise they get
+// the value the path has in fallback, the configs value is merged over, as a nil
+// fallback holds nothing. The other substitutions are returned unresolved.
+func ResolveSelfReferences(value, fallback *HoconValue, substitutions []*HoconSubstitution) ([]*HoconSubstitution, error) {
+	// selfReferences tells whether each one refers to fallback
+	selfReferences := map[*HoconSubstitution]bool{}
+	value.collectSelfReferences(Path{}, nil, selfReferences)
+
+	var others []*HoconSubstitution
+	for _, sub := range substitutions {
+		toFallback, exist := selfReferences[sub]
+		if !exist {
+			others = append(others, sub)
+			continue
+		}
 
-	cRoot := root.Value()
+		if !toFallback {
+			if err := resolveSubstitution(value, sub); err != nil {
+				return nil, err
+			}
+			continue
+		}
 
-	for _, sub := range p.substitutions {
-		res, err := getNode(cRoot, sub.Path)
-		if err != nil {
+		if err := resolveSubstitution(fallback, sub); err != nil {
 			return nil, err
 		}
 
-		if res == nil {
-			envVal, exist := os.LookupEnv(sub.OriginalPath)
-			if !exist {
-				if !sub.IsOptional {
-					return nil, fmt.Errorf("unresolved substitution: %s", sub.Path)
-				}
-			} else {
-				hv := NewHoconValue()
-				hv.AppendValue(NewHoconLiteral(envVal))
-				sub.ResolvedValue = hv
+		if resolved := sub.ResolvedValue; resolved != nil && resolved.oldValue != nil {
+			// topValueOfSub would read the previous value of the path in fallback
+			sub.ResolvedValue = &HoconValue{values: resolved.values, origin: resolved.origin, comments: resolved.comments}
+		}
+	}
+
+	return others, nil
+}
+
+// collectSelfReferences walks the value set at path, nodes holding the values set at
+// each parent of the path from the root.
+func (p *HoconValue) collectSelfReferences(path Path, nodes []*HoconValue, found map[*HoconSubstitution]bool) {
+	if p == nil {
+		return
+	}
+
+	nodes = append(nodes, p)
+	for _, element := range p.values {
+		switch v := element.(type) {
+		case *HoconSubstitution:
+			subPath, err := ParsePath(v.Path)
+			if err != nil || len(subPath) == 0 || !path.HasPrefix(subPath) {
+				continue
+			}
+
+			// an earlier setting of the referred path is found within the value
+			found[v] = nodes[len(subPath)].oldValue == nil
+		case *HoconObject:
+			for _, key := range v.keys {
+				v.items[key].collectSelfReferences(path.Child(key), nodes, found)
+			}
+		case *HoconArray:
+			for i, element := range v.values {
+				element.collectSelfReferences(path.Child(strconv.Itoa(i)), nodes, found)
+			}
+		case *HoconValue:
+			v.collectSelfReferences(path, nodes[:len(nodes)-1], found)
+		}
+	}
+
+	p.oldValue.collectSelfReferences(path, nodes[:len(nodes)-1], found)
+}
+
+func resolveSubstitution(root *HoconValue, sub *HoconSubstitution) error {
+	path, err := ParsePath(sub.Path)
+	if err != nil {
+		return err
+	}
+
+	var res *HoconValue
+	if root != nil {
+		res, err = root.GetNode(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	if res == nil {
+		envVal, exist := os.LookupEnv(sub.OriginalPath)
+		if !exist {
+			if !sub.IsOptional {
+				return fmt.Errorf("unresolved substitution: %s", sub.Path)
 			}
 		} else {
-			sub.ResolvedValue = res
+			hv := NewHoconValue()
+			hv.AppendValue(NewHoconLiteral(envVal))
+			sub.ResolvedValue = hv
+		}
+	} else {
+		sub.ResolvedValue = res
+	}
+
+	return nil
+}
+
+func (p *Parser) parseText(text string, callback IncludeCallback, resolve bool) (*HoconRoot, error) {
+	p.callback = callback
+	p.root = NewHoconValue()
+	p.reader = NewHoconTokenizer(text)
+	p.reader.PullWhitespaceAndComments()
+
+	if err := p.parseObject(p.root, true, Path{}); err != nil {
+		return nil, err
+	}
+
+	if resolve {
+		if err := ResolveSubstitutions(p.root, p.substitutions); err != nil {
+			return nil, err
 		}
 	}
 
 	return NewHoconRoot(p.root, p.substitutions...), nil
 }
 
-func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) error {
+func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath Path) error {
 	if !owner.IsObject() {
 		owner.NewValue(NewHoconObject())
 	} else {
@@ -104,7 +223,11 @@ func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) e
 
 			substitutions := included.substitutions
 			for _, substitution := range substitutions {
-				substitution.Path = currentPath + "." + substitution.Path
+				path, err := ParsePath(substitution.Path)
+				if err != nil {
+					return err
+				}
+				substitution.Path = currentPath.Child(path...).Render()
 			}
 			p.substitutions = append(p.substitutions, substitutions...)
 			otherObj, err := included.value.GetObject()
@@ -120,12 +243,14 @@ func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) e
 			objectV.Merge(otherObj)
 		case TokenTypeEoF:
 		case TokenTypeKey:
+			if root {
+				p.comments = p.reader.TakeComments()
+			}
 			value := currentObject.GetOrCreateKey(t.value)
-			nextPath := t.value
-			if len(currentPath) > 0 {
-				nextPath = currentPath + "." + t.value
+			if len(p.origin) > 0 {
+				value.origin = NewHoconOrigin(p.origin, p.reader.Line())
 			}
-			if err := p.parseKeyContent(value, nextPath); err != nil {
+			if err := p.parseKeyContent(value, currentPath.Child(t.value)); err != nil {
 				return err
 			}
 			if !root {
@@ -138,13 +263,18 @@ func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) e
 	return nil
 }
 
-func (p *Parser) parseKeyContent(value *HoconValue, currentPath string) error {
+func (p *Parser) parseKeyContent(value *HoconValue, currentPath Path) error {
 	for !p.reader.EOF() {
 		t, err := p.reader.PullNext()
 		if err != nil {
 			return err
 		}
 
+		if t.tokenType != TokenTypeDot && len(p.comments) > 0 {
+			value.comments = p.comments
+			p.comments = nil
+		}
+
 		switch t.tokenType {
 		case TokenTypeDot:
 			return p.parseObject(value, false, currentPath)
@@ -169,7 +299,7 @@ func (p *Parser) parseKeyContent(value *HoconValue, currentPath string) error {
 	return nil
 }
 
-func (p *Parser) ParseValue(owner *HoconValue, isEqualPlus bool, currentPath string) error {
+func (p *Parser) ParseValue(owner *HoconValue, isEqualPlus bool, currentPath Path) error {
 	if p.reader.EOF() {
 		return errors.New("end of file reached while trying to read a value")
 	}
@@ -183,7 +313,7 @@ func (p *Parser) ParseValue(owner *HoconValue, isEqualPlus bool, currentPath str
 		}
 
 		if isEqualPlus {
-			sub := p.ParseSubstitution(currentPath, false)
+			sub := p.ParseSubstitution(currentPath.Render(), false)
 			p.substitutions = append(p.substitutions, sub)
 			owner.AppendValue(sub)
 		}
@@ -195,6 +325,9 @@ func (p *Parser) ParseValue(owner *HoconValue, isEqualPlus bool, currentPath str
 				owner.Clear()
 			}
 			lit := NewHoconLiteral(t.value)
+			if t.isQuoted {
+				lit = NewHoconQuotedLiteral(t.value)
+			}
 			owner.AppendValue(lit)
 		case TokenTypeObjectStart:
 			if err := p.parseObject(owner, true, currentPath); err != nil {
@@ -235,7 +368,7 @@ func (p *Parser) ParseSubstitution(value string, isOptional bool) *HoconSubstitu
 	return NewHoconSubstitution(value, isOptional)
 }
 
-func (p *Parser) ParseArray(currentPath string) (HoconArray, error) {
+func (p *Parser) ParseArray(currentPath Path) (HoconArray, error) {
 	arr := NewHoconArray()
 	for !p.reader.EOF() && !p.reader.IsArrayEnd() {
 		v := NewHoconValue()
@@ -260,35 +393,3 @@ func (p *Parser) ignoreNewline() {
 		p.reader.PullNewline()
 	}
 }
-
-func getNode(root *HoconValue, path string) (*HoconValue, error) {
-	elements := splitDottedPathHonouringQuotes(path)
-	currentNode := root
-
-	if currentNode == nil {
-		return nil, errors.New("current node should not be null")
-	}
-
-	for _, key := range elements {
-		var err error
-		currentNode, err = currentNode.GetChildObject(key)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return currentNode, nil
-}
-
-func splitDottedPathHonouringQuotes(path string) []string {
-	tmp1 := strings.Split(path, "\"")
-	var values []string
-	for i := 0; i < len(tmp1); i++ {
-		tmp2 := strings.Split(tmp1[i], ".")
-		for j := 0; j < len(tmp2); j++ {
-			if len(tmp2[j]) > 0 {
-				values = append(values, tmp2[j])
-			}
-		}
-	}
-	return values
-}
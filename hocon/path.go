@@ -0,0 +1,234 @@
+package hocon
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path is a sequence of keys addressing a value, the empty path addresses the root.
+type Path []string
+
+// PathOf builds a path out of raw keys, which are never split nor unquoted.
+func PathOf(keys ...string) Path {
+	path := make(Path, len(keys))
+	copy(path, keys)
+	return path
+}
+
+// ParsePath parses a path expression such as a."b.c".d: keys are separated by dots
+// and quoted keys may contain dots, quotes and escape sequences. Whitespace inside an
+// unquoted key is kept, leading and trailing whitespace of the expression is ignored.
+// Array elements are addressed by index, either as servers.0.host or servers[0].host.
+// The empty expression gives the empty path.
+func ParsePath(path string) (Path, error) {
+	text := strings.TrimSpace(path)
+	if len(text) == 0 {
+		return Path{}, nil
+	}
+
+	var keys Path
+	var key strings.Builder
+	hasKey := false
+	// afterIndex is set right after a closing bracket, where only a dot or another index may follow
+	afterIndex := false
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if afterIndex && c != '.' && c != '[' {
+			return nil, fmt.Errorf("expected a dot after index in path %q", path)
+		}
+
+		switch c {
+		case '.':
+			if !hasKey && !afterIndex {
+				return nil, fmt.Errorf("empty key in path %q", path)
+			}
+			if hasKey {
+				keys = append(keys, key.String())
+				key.Reset()
+			}
+			hasKey, afterIndex = false, false
+		case '[':
+			if !hasKey && !afterIndex {
+				return nil, fmt.Errorf("index without key in path %q", path)
+			}
+			end := strings.IndexByte(text[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index in path %q", path)
+			}
+			index := text[i+1 : i+end]
+			if _, err := strconv.ParseUint(index, 10, 0); err != nil {
+				return nil, fmt.Errorf("invalid index %q in path %q", index, path)
+			}
+			if hasKey {
+				keys = append(keys, key.String())
+				key.Reset()
+			}
+			keys = append(keys, index)
+			hasKey, afterIndex = false, true
+			i += end
+		case '"':
+			unquoted, n, err := unquoteString(text[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %s", path, err)
+			}
+			key.WriteString(unquoted)
+			hasKey = true
+			i += n - 1
+		default:
+			key.WriteByte(c)
+			hasKey = true
+		}
+	}
+
+	if afterIndex {
+		return keys, nil
+	}
+
+	if !hasKey {
+		return nil, fmt.Errorf("empty key in path %q", path)
+	}
+
+	return append(keys, key.String()), nil
+}
+
+// Render returns the path expression of the path, quoting the keys which need it.
+func (p Path) Render() string {
+	rendered := make([]string, len(p))
+	for i, key := range p {
+		rendered[i] = quoteKeyIfNeeded(key)
+	}
+	return strings.Join(rendered, ".")
+}
+
+func (p Path) String() string {
+	return p.Render()
+}
+
+// Parent returns the path without its last key, nil for the root or a single key.
+func (p Path) Parent() Path {
+	if len(p) <= 1 {
+		return nil
+	}
+	return PathOf(p[:len(p)-1]...)
+}
+
+// Last returns the last key of the path, empty for the root.
+func (p Path) Last() string {
+	if len(p) == 0 {
+		return ""
+	}
+	return p[len(p)-1]
+}
+
+// Child returns a new path with the keys appended.
+func (p Path) Child(keys ...string) Path {
+	child := make(Path, 0, len(p)+len(keys))
+	child = append(child, p...)
+	return append(child, keys...)
+}
+
+// Equal checks whether both paths have the same keys.
+func (p Path) Equal(other Path) bool {
+	if len(p) != len(other) {
+		return false
+	}
+
+	for i := range p {
+		if p[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPrefix checks whether the path starts with the keys of prefix.
+func (p Path) HasPrefix(prefix Path) bool {
+	return len(prefix) <= len(p) && p[:len(prefix)].Equal(prefix)
+}
+
+// quoteKeyIfNeeded quotes a key unless it can be written as an unquoted path element
+func quoteKeyIfNeeded(key string) string {
+	if len(key) == 0 ||
+		strings.ContainsAny(key, HoconNotInUnquotedKey) ||
+		strings.IndexFunc(key, isWhitespaceOrControlRune) >= 0 ||
+		strings.Contains(key, "//") {
+		return quoteString(key)
+	}
+	return key
+}
+
+func isWhitespaceOrControlRune(r rune) bool {
+	if r < 0x20 {
+		return true
+	}
+
+	for _, ws := range whitespaceTokens {
+		if string(r) == ws {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteString wraps text in quotes, escaping it as a JSON string.
+func quoteString(text string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range text {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// unquoteString reads the quoted string text starts with, returning its unescaped
+// content and the number of bytes read.
+func unquoteString(text string) (string, int, error) {
+	tokenizer := NewHoconTokenizer(text)
+	tokenizer.TakeOne()
+
+	var sb strings.Builder
+	for !tokenizer.EOF() {
+		if tokenizer.Matches(endOfQuotedTextToken) {
+			tokenizer.TakeOne()
+			return sb.String(), tokenizer.index, nil
+		}
+
+		if tokenizer.Matches(escapeChar) {
+			sequence, err := tokenizer.pullEscapeSequence()
+			if err != nil {
+				return "", 0, err
+			}
+			sb.WriteString(sequence)
+			continue
+		}
+
+		sb.WriteByte(tokenizer.TakeOne())
+	}
+
+	return "", 0, errors.New("unterminated quoted string")
+}
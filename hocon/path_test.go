@@ -0,0 +1,163 @@
+package hocon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    Path
+		wantErr bool
+	}{
+		{
+			name: "parses dotted path",
+			path: "a.b.c",
+			want: Path{"a", "b", "c"},
+		},
+		{
+			name: "keeps dots of quoted keys",
+			path: `a."b.c".d`,
+			want: Path{"a", "b.c", "d"},
+		},
+		{
+			name: "unescapes quoted keys",
+			path: `"say \"hi\"".x`,
+			want: Path{`say "hi"`, "x"},
+		},
+		{
+			name: "concatenates quoted and unquoted parts",
+			path: `a"b.c"d`,
+			want: Path{"ab.cd"},
+		},
+		{
+			name: "keeps whitespace inside keys",
+			path: " a b.c ",
+			want: Path{"a b", "c"},
+		},
+		{
+			name: "parses empty quoted key",
+			path: `a."".b`,
+			want: Path{"a", "", "b"},
+		},
+		{
+			name: "parses empty path as root",
+			path: "",
+			want: Path{},
+		},
+		{
+			name: "parses array indices",
+			path: "servers[0].hosts[1][2]",
+			want: Path{"servers", "0", "hosts", "1", "2"},
+		},
+		{
+			name: "parses dotted array indices",
+			path: "servers.0.host",
+			want: Path{"servers", "0", "host"},
+		},
+		{
+			name:    "fails on invalid index",
+			path:    "servers[a]",
+			wantErr: true,
+		},
+		{
+			name:    "fails on key right after index",
+			path:    "servers[0]host",
+			wantErr: true,
+		},
+		{
+			name:    "fails on unterminated index",
+			path:    "servers[0",
+			wantErr: true,
+		},
+		{
+			name:    "fails on empty unquoted key",
+			path:    "a..b",
+			wantErr: true,
+		},
+		{
+			name:    "fails on trailing dot",
+			path:    "a.",
+			wantErr: true,
+		},
+		{
+			name:    "fails on unterminated quote",
+			path:    `a."b`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePath() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPath_Render(t *testing.T) {
+	tests := []struct {
+		name string
+		path Path
+		want string
+	}{
+		{
+			name: "renders simple keys unquoted",
+			path: PathOf("akka", "log-dead-letters", "0"),
+			want: "akka.log-dead-letters.0",
+		},
+		{
+			name: "quotes keys with dots, quotes and spaces",
+			path: PathOf("a.b", `c"d`, "e f", ""),
+			want: `"a.b"."c\"d"."e f".""`,
+		},
+		{
+			name: "quotes keys with control characters",
+			path: PathOf("a\nb", "c\u0001"),
+			want: `"a\nb"."c\u0001"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.path.Render()
+			if got != tt.want {
+				t.Errorf("Render() = %v, want %v", got, tt.want)
+			}
+
+			parsed, err := ParsePath(got)
+			if err != nil {
+				t.Fatalf("ParsePath() error = %v", err)
+			}
+			if !parsed.Equal(tt.path) {
+				t.Errorf("ParsePath(Render()) = %#v, want %#v", parsed, tt.path)
+			}
+		})
+	}
+}
+
+func TestPath_ParentAndLast(t *testing.T) {
+	path := PathOf("a", "b", "c")
+
+	if got := path.Parent(); !got.Equal(PathOf("a", "b")) {
+		t.Errorf("Parent() = %v, want a.b", got)
+	}
+	if got := path.Last(); got != "c" {
+		t.Errorf("Last() = %v, want c", got)
+	}
+	if got := PathOf("a").Parent(); got != nil {
+		t.Errorf("Parent() = %v, want nil", got)
+	}
+	if got := (Path{}).Last(); got != "" {
+		t.Errorf("Last() = %v, want empty", got)
+	}
+	if path.Equal(PathOf("a", "b")) || !path.Equal(path.Parent().Child("c")) {
+		t.Errorf("Equal() does not compare keys")
+	}
+}
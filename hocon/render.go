@@ -0,0 +1,308 @@
+package hocon
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultRenderIndent  = "  "
+	defaultRenderNewline = "\n"
+)
+
+// RenderOptions tunes HoconValue.Render.
+type RenderOptions struct {
+	// JSON renders valid JSON, otherwise HOCON.
+	JSON bool
+	// Formatted spreads objects and arrays over several indented lines, the output is
+	// a single line otherwise.
+	Formatted bool
+	// OriginComments adds a comment telling where each value was defined. It only
+	// applies to formatted HOCON, as JSON and single lines cannot hold comments.
+	OriginComments bool
+	// Comments keeps the comments documenting the keys. Like OriginComments, it only
+	// applies to formatted HOCON.
+	Comments bool
+	// SortKeys renders the keys of every object in lexical order instead of the
+	// document order.
+	SortKeys bool
+	// Indent is repeated once per nesting level, two spaces if empty.
+	Indent string
+	// Newline ends every line, "\n" if empty.
+	Newline string
+}
+
+// Render returns the text of the value, concise HOCON by default. Strings are quoted
+// whenever needed, so that the rendered text parses back to an equal value.
+// Substitutions which are not resolved are kept as ${path} in HOCON and become
+// null in JSON.
+func (p *HoconValue) Render(opts ...RenderOptions) string {
+	var opt RenderOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if len(opt.Indent) == 0 {
+		opt.Indent = defaultRenderIndent
+	}
+
+	if len(opt.Newline) == 0 {
+		opt.Newline = defaultRenderNewline
+	}
+
+	r := &renderer{opt: opt}
+
+	if p.IsObject() && opt.Formatted && !opt.JSON {
+		objectV, err := p.GetObject()
+		// must not return error after checking p.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		r.renderMembers(objectV, 0)
+		return r.sb.String()
+	}
+
+	r.renderValue(p, 0)
+	if opt.Formatted {
+		r.sb.WriteString(opt.Newline)
+	}
+	return r.sb.String()
+}
+
+type renderer struct {
+	opt RenderOptions
+	// canonical renders numbers by value, see canonicalLeaf
+	canonical bool
+	sb        strings.Builder
+}
+
+func (r *renderer) renderValue(value *HoconValue, depth int) {
+	if sub := value.unresolvedSubstitution(); sub != nil {
+		if r.opt.JSON {
+			r.sb.WriteString("null")
+			return
+		}
+
+		r.sb.WriteString("${")
+		if sub.IsOptional {
+			r.sb.WriteString("?")
+		}
+		r.sb.WriteString(sub.OriginalPath)
+		r.sb.WriteString("}")
+		return
+	}
+
+	if value.IsObject() {
+		objectV, err := value.GetObject()
+		// must not return error after checking value.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		r.renderObject(objectV, depth)
+		return
+	}
+
+	if value.IsArray() {
+		arrayV, err := value.GetArray()
+		// must not return error after checking value.IsArray()
+		if err != nil {
+			panic(err)
+		}
+
+		r.renderArray(arrayV, depth)
+		return
+	}
+
+	leaf, err := value.Unwrapped()
+	if err != nil {
+		r.sb.WriteString(unknownValue)
+		return
+	}
+
+	if r.canonical {
+		r.sb.WriteString(canonicalLeaf(leaf))
+		return
+	}
+	r.sb.WriteString(renderLeaf(leaf))
+}
+
+func (r *renderer) renderObject(obj *HoconObject, depth int) {
+	if len(obj.keys) == 0 {
+		r.sb.WriteString("{}")
+		return
+	}
+
+	r.sb.WriteString("{")
+	if r.opt.Formatted {
+		r.sb.WriteString(r.opt.Newline)
+	}
+
+	r.renderMembers(obj, depth+1)
+
+	if r.opt.Formatted {
+		r.writeIndent(depth)
+	}
+	r.sb.WriteString("}")
+}
+
+func (r *renderer) renderMembers(obj *HoconObject, depth int) {
+	keys := obj.keys
+	if r.opt.SortKeys {
+		keys = append([]string(nil), keys...)
+		sort.Strings(keys)
+	}
+
+	for i, key := range keys {
+		value := obj.items[key]
+
+		if i > 0 && !r.opt.Formatted {
+			r.sb.WriteString(",")
+		}
+
+		r.renderComment(value, depth)
+
+		if r.opt.Formatted {
+			r.writeIndent(depth)
+		}
+
+		if r.opt.JSON {
+			r.sb.WriteString(quoteString(key))
+		} else {
+			r.sb.WriteString(quoteKeyIfNeeded(key))
+		}
+
+		switch {
+		case r.opt.JSON && r.opt.Formatted:
+			r.sb.WriteString(" : ")
+		case r.opt.JSON:
+			r.sb.WriteString(":")
+		case r.opt.Formatted && value.IsObject() && value.unresolvedSubstitution() == nil:
+			r.sb.WriteString(" ")
+		case r.opt.Formatted:
+			r.sb.WriteString(" = ")
+		default:
+			r.sb.WriteString("=")
+		}
+
+		r.renderValue(value, depth)
+
+		if r.opt.Formatted {
+			if r.opt.JSON && i < len(keys)-1 {
+				r.sb.WriteString(",")
+			}
+			r.sb.WriteString(r.opt.Newline)
+		}
+	}
+}
+
+func (r *renderer) renderArray(values []*HoconValue, depth int) {
+	if len(values) == 0 {
+		r.sb.WriteString("[]")
+		return
+	}
+
+	r.sb.WriteString("[")
+	if r.opt.Formatted {
+		r.sb.WriteString(r.opt.Newline)
+	}
+
+	for i, value := range values {
+		if i > 0 && !r.opt.Formatted {
+			r.sb.WriteString(",")
+		}
+
+		r.renderComment(value, depth+1)
+
+		if r.opt.Formatted {
+			r.writeIndent(depth + 1)
+		}
+
+		r.renderValue(value, depth+1)
+
+		if r.opt.Formatted {
+			if r.opt.JSON && i < len(values)-1 {
+				r.sb.WriteString(",")
+			}
+			r.sb.WriteString(r.opt.Newline)
+		}
+	}
+
+	if r.opt.Formatted {
+		r.writeIndent(depth)
+	}
+	r.sb.WriteString("]")
+}
+
+func (r *renderer) renderComment(value *HoconValue, depth int) {
+	if !r.opt.Formatted || r.opt.JSON {
+		return
+	}
+
+	if r.opt.Comments {
+		for _, comment := range value.Comments() {
+			r.writeComment(comment, depth)
+		}
+	}
+
+	if r.opt.OriginComments && value.Origin() != nil {
+		r.writeComment(value.Origin().String(), depth)
+	}
+}
+
+func (r *renderer) writeComment(comment string, depth int) {
+	r.writeIndent(depth)
+	r.sb.WriteString("#")
+	if len(comment) > 0 {
+		r.sb.WriteString(" ")
+		r.sb.WriteString(strings.Replace(comment, "\n", " ", -1))
+	}
+	r.sb.WriteString(r.opt.Newline)
+}
+
+func (r *renderer) writeIndent(depth int) {
+	r.sb.WriteString(strings.Repeat(r.opt.Indent, depth))
+}
+
+// renderLeaf renders a value unwrapped from a leaf, strings are always quoted.
+func renderLeaf(leaf interface{}) string {
+	switch v := leaf.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case json.Number:
+		return string(v)
+	case float64:
+		text := strconv.FormatFloat(v, 'g', -1, 64)
+		// keep a fraction so that the number is parsed back as a float
+		if !strings.ContainsAny(text, ".eE") {
+			text += ".0"
+		}
+		// + is not allowed in unquoted text
+		return strings.Replace(text, "e+", "e", 1)
+	case string:
+		return quoteString(v)
+	}
+
+	return unknownValue
+}
+
+// unresolvedSubstitution returns the substitution p is made of when it has not
+// been resolved yet, nil otherwise.
+func (p *HoconValue) unresolvedSubstitution() *HoconSubstitution {
+	if p == nil || len(p.values) != 1 {
+		return nil
+	}
+
+	sub, ok := p.values[0].(*HoconSubstitution)
+	if !ok || sub.ResolvedValue != nil {
+		return nil
+	}
+	return sub
+}
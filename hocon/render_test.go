@@ -0,0 +1,141 @@
+package hocon
+
+import (
+	"testing"
+)
+
+func TestHoconValue_Render(t *testing.T) {
+	const text = `
+a.b = 1
+"x.y" = "q\"uote"
+c { d = [1, 2.5, { e = null }], f = true }
+empty {}
+`
+	tests := []struct {
+		name string
+		opts RenderOptions
+		want string
+	}{
+		{
+			name: "concise HOCON by default",
+			want: `{a={b=1},"x.y"="q\"uote",c={d=[1,2.5,{e=null}],f=true},empty={}}`,
+		},
+		{
+			name: "concise JSON",
+			opts: RenderOptions{JSON: true},
+			want: `{"a":{"b":1},"x.y":"q\"uote","c":{"d":[1,2.5,{"e":null}],"f":true},"empty":{}}`,
+		},
+		{
+			name: "formatted HOCON without root braces",
+			opts: RenderOptions{Formatted: true},
+			want: "a {\n  b = 1\n}\n\"x.y\" = \"q\\\"uote\"\nc {\n  d = [\n    1\n    2.5\n    {\n      e = null\n    }\n  ]\n  f = true\n}\nempty {}\n",
+		},
+		{
+			name: "formatted JSON with custom indent and newline",
+			opts: RenderOptions{JSON: true, Formatted: true, Indent: "\t", Newline: "\r\n"},
+			want: "{\r\n\t\"a\" : {\r\n\t\t\"b\" : 1\r\n\t},\r\n\t\"x.y\" : \"q\\\"uote\",\r\n\t\"c\" : {\r\n\t\t\"d\" : [\r\n\t\t\t1,\r\n\t\t\t2.5,\r\n\t\t\t{\r\n\t\t\t\t\"e\" : null\r\n\t\t\t}\r\n\t\t],\r\n\t\t\"f\" : true\r\n\t},\r\n\t\"empty\" : {}\r\n}\r\n",
+		},
+		{
+			name: "origin comments are ignored when not formatted",
+			opts: RenderOptions{OriginComments: true},
+			want: `{a={b=1},"x.y"="q\"uote",c={d=[1,2.5,{e=null}],f=true},empty={}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := ParseWithOptions(text, nil, ParseOptions{Origin: "test.conf"})
+			if err != nil {
+				t.Fatalf("ParseWithOptions() error = %v", err)
+			}
+
+			if got := root.Value().Render(tt.opts); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoconValue_RenderOriginComments(t *testing.T) {
+	root, err := ParseWithOptions("a = 1\nb {\n  c = x\n}\n", nil, ParseOptions{Origin: "test.conf"})
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+
+	want := "# test.conf: 1\na = 1\n# test.conf: 2\nb {\n  # test.conf: 3\n  c = \"x\"\n}\n"
+	if got := root.Value().Render(RenderOptions{Formatted: true, OriginComments: true}); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestHoconValue_RenderLeaves(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "quoted number stays a string",
+			text: `a = "10"`,
+			want: `{a="10"}`,
+		},
+		{
+			name: "float keeps a fraction",
+			text: `a = 1e3`,
+			want: `{a=1000.0}`,
+		},
+		{
+			name: "exponents have no plus sign",
+			text: `a = [1e21, 1e-7, -2.5E30]`,
+			want: `{a=[1e21,1e-07,-2.5e30]}`,
+		},
+		{
+			name: "integer too large for an int64 keeps its digits",
+			text: `a = 99999999999999999999`,
+			want: `{a=99999999999999999999}`,
+		},
+		{
+			name: "unquoted text is quoted",
+			text: `a = hello world`,
+			want: `{a="hello world"}`,
+		},
+		{
+			name: "control characters are escaped",
+			text: `a = "tab\there"`,
+			want: `{a="tab\there"}`,
+		},
+		{
+			name: "keys are quoted when needed",
+			text: `"a b" { "c.d" = 1, "" = 2 }`,
+			want: `{"a b"={"c.d"=1,""=2}}`,
+		},
+		{
+			name: "unresolved substitutions are kept",
+			text: `a = ${b}, c = ${?d}`,
+			want: `{a=${b},c=${?d}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := ParseUnresolved(tt.text, nil)
+			if err != nil {
+				t.Fatalf("ParseUnresolved() error = %v", err)
+			}
+
+			if got := root.Value().Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoconValue_RenderComments(t *testing.T) {
+	root, err := ParseWithOptions("# first\n# second\na = 1\nb {\n  # nested\n  c = x\n}\n", nil, ParseOptions{Origin: "test.conf"})
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+
+	want := "# first\n# second\n# test.conf: 3\na = 1\n# test.conf: 4\nb {\n  # nested\n  # test.conf: 6\n  c = \"x\"\n}\n"
+	if got := root.Value().Render(RenderOptions{Formatted: true, Comments: true, OriginComments: true}); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -80,18 +80,24 @@ func (p *HoconSubstitution) checkCycleRef() error {
 }
 
 func (p *HoconSubstitution) hasCycleRef(dup map[HoconElement]int, level int) bool {
-	if p.ResolvedValue == nil {
+	resolved := p.ResolvedValue
+	if resolved == nil {
 		return false
 	}
 
-	if lvl, exist := dup[p.ResolvedValue]; exist {
+	// a path referring to itself reads its earlier setting, see topValueOfSub
+	if resolved.oldValue != nil {
+		resolved = resolved.oldValue
+	}
+
+	if lvl, exist := dup[resolved]; exist {
 		if lvl != level {
 			return true
 		}
 	}
-	dup[p.ResolvedValue] = level
+	dup[resolved] = level
 
-	for _, subV := range p.ResolvedValue.values {
+	for _, subV := range resolved.values {
 		if sub, ok := subV.(*HoconSubstitution); ok {
 			if sub.ResolvedValue != nil {
 				return sub.hasCycleRef(dup, level+1)
@@ -27,6 +27,7 @@ type Token struct {
 	tokenType  TokenType
 	value      string
 	isOptional bool
+	isQuoted   bool
 }
 
 func NewToken(v interface{}) *Token {
@@ -57,6 +58,11 @@ func NewTokenLiteralValue(value string) *Token {
 	return &Token{tokenType: TokenTypeLiteralValue, value: value}
 }
 
+// NewTokenQuotedLiteralValue creates a literal token read from a quoted string.
+func NewTokenQuotedLiteralValue(value string) *Token {
+	return &Token{tokenType: TokenTypeLiteralValue, value: value, isQuoted: true}
+}
+
 func NewTokenInclude(path string) *Token {
 	return &Token{tokenType: TokenTypeInclude, value: path}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -79,6 +80,9 @@ type Tokenizer struct {
 	text       string
 	index      int
 	indexStack *Stack
+
+	lineIndex int
+	line      int
 }
 
 func NewTokenizer(text string) *Tokenizer {
@@ -102,6 +106,29 @@ func (p *Tokenizer) Pop() error {
 	return nil
 }
 
+// Line returns the 1-based line number of the current position.
+func (p *Tokenizer) Line() int {
+	if p == nil {
+		return 0
+	}
+
+	if p.line == 0 || p.index < p.lineIndex {
+		p.lineIndex, p.line = 0, 1
+	}
+
+	end := p.index
+	if end > len(p.text) {
+		end = len(p.text)
+	}
+
+	if end > p.lineIndex {
+		p.line += strings.Count(p.text[p.lineIndex:end], "\n")
+		p.lineIndex = end
+	}
+
+	return p.line
+}
+
 func (p *Tokenizer) EOF() bool {
 	if p == nil {
 		return false
@@ -185,10 +212,14 @@ func (p *HoconTokenizer) PullWhitespace() {
 
 type HoconTokenizer struct {
 	*Tokenizer
+	// comments are the lines of the comment block read last, commentLine the line
+	// it ends on
+	comments    []string
+	commentLine int
 }
 
 func NewHoconTokenizer(text string) *HoconTokenizer {
-	return &HoconTokenizer{NewTokenizer(text)}
+	return &HoconTokenizer{Tokenizer: NewTokenizer(text)}
 }
 
 func (p *HoconTokenizer) PullWhitespaceAndComments() {
@@ -368,10 +399,61 @@ func (p *HoconTokenizer) IsStartOfTripleQuotedText() bool {
 }
 
 func (p *HoconTokenizer) PullComment() *Token {
-	p.PullRestOfLine()
+	ownLine := p.isAtLineStart()
+	line := p.Line()
+	text := p.PullRestOfLine()
+
+	if !ownLine {
+		p.comments = nil
+		return NewToken(TokenTypeComment)
+	}
+
+	if line != p.commentLine+1 {
+		p.comments = nil
+	}
+
+	for _, start := range startOfCommentTokens {
+		if strings.HasPrefix(text, start) {
+			text = strings.TrimPrefix(text[len(start):], " ")
+			break
+		}
+	}
+	p.comments = append(p.comments, text)
+	p.commentLine = line
 	return NewToken(TokenTypeComment)
 }
 
+// TakeComments returns the comment block ending right above the current line, which
+// documents the key read on it, and forgets it.
+func (p *HoconTokenizer) TakeComments() []string {
+	comments := p.comments
+	p.comments = nil
+
+	if p.Tokenizer == nil || p.commentLine != p.Line()-1 {
+		return nil
+	}
+	return comments
+}
+
+// isAtLineStart checks whether only whitespace precedes the current position on its line.
+func (p *HoconTokenizer) isAtLineStart() bool {
+	if p.Tokenizer == nil {
+		return false
+	}
+
+	for i := p.index - 1; i >= 0; i-- {
+		switch p.text[i] {
+		case '\n':
+			return true
+		case ' ', '\t', '\r':
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func (p *HoconTokenizer) PullUnquotedKey() *Token {
 	buf := bytes.NewBuffer(nil)
 	for !p.EOF() && p.IsUnquotedKey() {
@@ -427,7 +509,7 @@ func (p *HoconTokenizer) PullTripleQuotedText() (*Token, error) {
 		p.TakeOne()
 	}
 	p.Take(3)
-	return NewTokenLiteralValue(buf.String()), nil
+	return NewTokenQuotedLiteralValue(buf.String()), nil
 }
 
 func (p *HoconTokenizer) PullQuotedText() (*Token, error) {
@@ -456,7 +538,7 @@ func (p *HoconTokenizer) PullQuotedText() (*Token, error) {
 		}
 	}
 	p.TakeOne()
-	return NewTokenLiteralValue(buf.String()), nil
+	return NewTokenQuotedLiteralValue(buf.String()), nil
 }
 
 func (p *HoconTokenizer) PullQuotedKey() (*Token, error) {
@@ -524,12 +606,12 @@ func (p *HoconTokenizer) pullEscapeSequence() (string, error) {
 	case 't':
 		return "\t", nil
 	case 'u':
-		utf8Code := "\\u" + strings.ToLower(p.Take(4))
-		utf8Str := ""
-		if _, err := fmt.Sscanf(utf8Code, "%s", &utf8Str); err != nil {
-			return "", err
+		hex := p.Take(4)
+		code, err := strconv.ParseUint(hex, 16, 16)
+		if err != nil {
+			return "", fmt.Errorf("invalid unicode escape: \\u%s", hex)
 		}
-		return utf8Str, nil
+		return string(rune(code)), nil
 	default:
 		return "", fmt.Errorf("unknown escape code: %v", escaped)
 	}
@@ -565,7 +647,7 @@ func (p *HoconTokenizer) PullValue() (*Token, error) {
 	}
 
 	if p.IsSubstitutionStart() {
-		return p.pullSubstitution(), nil
+		return p.pullSubstitution()
 	}
 
 	return nil, fmt.Errorf("expected value: Null literal, Array, Quoted Text, Unquoted Text, Triple quoted Text, Object or End of array")
@@ -601,7 +683,7 @@ func (p *HoconTokenizer) IsInclude() bool {
 	return false
 }
 
-func (p *HoconTokenizer) pullSubstitution() *Token {
+func (p *HoconTokenizer) pullSubstitution() (*Token, error) {
 	buf := bytes.NewBuffer(nil)
 	p.Take(2)
 	isOptional := false
@@ -610,14 +692,42 @@ func (p *HoconTokenizer) pullSubstitution() *Token {
 		isOptional = true
 	}
 
-	for !p.EOF() && p.isUnquotedText() {
+	for !p.EOF() && (p.isUnquotedText() || p.IsStartOfQuotedText() || p.Peek() == '[') {
+		if p.IsStartOfQuotedText() {
+			// quoted keys are kept as written, the path is parsed on resolution
+			start := p.index
+			if _, err := p.PullQuotedText(); err != nil {
+				return nil, err
+			}
+			buf.WriteString(p.text[start:p.index])
+			continue
+		}
+
+		if p.Peek() == '[' {
+			// array indices are kept as written too, ParsePath checks them
+			end := strings.IndexAny(p.text[p.index:], "]}\n")
+			if end < 0 || p.text[p.index+end] != ']' {
+				return nil, fmt.Errorf("unterminated index in substitution ${%s", buf.String())
+			}
+			buf.WriteString(p.Take(end + 1))
+			continue
+		}
+
 		if err := buf.WriteByte(p.TakeOne()); err != nil {
 			// Buffer.WriteByte cannot return error
 			panic(err)
 		}
 	}
+
+	if p.EOF() {
+		return nil, fmt.Errorf("unterminated substitution ${%s", buf.String())
+	}
+
+	if p.Peek() != '}' {
+		return nil, fmt.Errorf("expected end of substitution ${%s, got %q", buf.String(), string(p.Peek()))
+	}
 	p.TakeOne()
-	return NewTokenSubstitution(buf.String(), isOptional)
+	return NewTokenSubstitution(buf.String(), isOptional), nil
 }
 
 func (p *HoconTokenizer) IsSpaceOrTab() bool {
@@ -879,7 +879,7 @@ func TestHoconTokenizer_PullQuotedText(t *testing.T) {
 			fields: fields{
 				Tokenizer: NewTokenizer(`"` + simpleKey1 + `"`),
 			},
-			want: NewTokenLiteralValue(simpleKey1),
+			want: NewTokenQuotedLiteralValue(simpleKey1),
 		},
 		{
 			name: "fails with incorrect escaped char",
@@ -893,7 +893,7 @@ func TestHoconTokenizer_PullQuotedText(t *testing.T) {
 			fields: fields{
 				Tokenizer: NewTokenizer(`"\t` + simpleKey1 + `"`),
 			},
-			want: NewTokenLiteralValue("\t" + simpleKey1),
+			want: NewTokenQuotedLiteralValue("\t" + simpleKey1),
 		},
 	}
 	for _, tt := range tests {
@@ -1109,7 +1109,7 @@ func TestHoconTokenizer_PullTripleQuotedText(t *testing.T) {
 			fields: fields{
 				Tokenizer: NewTokenizer(startOfTripleQuotedTextToken + simpleKey1 + endOfTripleQuotedTextToken),
 			},
-			want: NewTokenLiteralValue(simpleKey1),
+			want: NewTokenQuotedLiteralValue(simpleKey1),
 		},
 		{
 			name: "fails with incorrect escaped char",
@@ -1123,7 +1123,7 @@ func TestHoconTokenizer_PullTripleQuotedText(t *testing.T) {
 			fields: fields{
 				Tokenizer: NewTokenizer(startOfTripleQuotedTextToken + `\t` + simpleKey1 + endOfTripleQuotedTextToken),
 			},
-			want: NewTokenLiteralValue(`\t` + simpleKey1),
+			want: NewTokenQuotedLiteralValue(`\t` + simpleKey1),
 		},
 	}
 	for _, tt := range tests {
@@ -1178,7 +1178,7 @@ func TestHoconTokenizer_PullValue(t *testing.T) {
 			fields: fields{
 				Tokenizer: NewTokenizer(startOfTripleQuotedTextToken + simpleKey1 + endOfTripleQuotedTextToken),
 			},
-			want: NewTokenLiteralValue(simpleKey1),
+			want: NewTokenQuotedLiteralValue(simpleKey1),
 		},
 		{
 			name: "fails with incorrect escaped char",
@@ -1192,7 +1192,7 @@ func TestHoconTokenizer_PullValue(t *testing.T) {
 			fields: fields{
 				Tokenizer: NewTokenizer(startOfTripleQuotedTextToken + `\t` + simpleKey1 + endOfTripleQuotedTextToken),
 			},
-			want: NewTokenLiteralValue(`\t` + simpleKey1),
+			want: NewTokenQuotedLiteralValue(`\t` + simpleKey1),
 		},
 	}
 	for _, tt := range tests {
@@ -1336,18 +1336,57 @@ func TestHoconTokenizer_pullSubstitution(t *testing.T) {
 		Tokenizer *Tokenizer
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		want   *Token
+		name    string
+		fields  fields
+		want    *Token
+		wantErr bool
 	}{
-		// TODO: Add test cases.
+		{
+			name:   "pulls substitution",
+			fields: fields{Tokenizer: &Tokenizer{text: "${a.b}"}},
+			want:   NewTokenSubstitution("a.b", false),
+		},
+		{
+			name:   "pulls optional substitution",
+			fields: fields{Tokenizer: &Tokenizer{text: "${?a.b}"}},
+			want:   NewTokenSubstitution("a.b", true),
+		},
+		{
+			name:   "keeps quoted keys of substitution",
+			fields: fields{Tokenizer: &Tokenizer{text: `${a."b.c".d}`}},
+			want:   NewTokenSubstitution(`a."b.c".d`, false),
+		},
+		{
+			name:   "keeps array indices of substitution",
+			fields: fields{Tokenizer: &Tokenizer{text: "${a[1].b[0][2]}"}},
+			want:   NewTokenSubstitution("a[1].b[0][2]", false),
+		},
+		{
+			name:    "fails on unterminated index",
+			fields:  fields{Tokenizer: &Tokenizer{text: "${a[1}"}},
+			wantErr: true,
+		},
+		{
+			name:    "fails on unexpected character",
+			fields:  fields{Tokenizer: &Tokenizer{text: "${a b}"}},
+			wantErr: true,
+		},
+		{
+			name:    "fails on unterminated substitution",
+			fields:  fields{Tokenizer: &Tokenizer{text: "${a.b"}},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &HoconTokenizer{
 				Tokenizer: tt.fields.Tokenizer,
 			}
-			got := p.pullSubstitution()
+			got, err := p.pullSubstitution()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("pullSubstitution() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("pullSubstitution() got = %v, want %v", got, tt.want)
 			}
@@ -1,10 +1,12 @@
 package hocon
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +15,8 @@ import (
 const infinite = "infinite"
 const unknownValue = "<<unknown value>>"
 
+var numberRegexp = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
 var (
 	_Num1000 = big.NewInt(1000)
 	_Num1024 = big.NewInt(1024)
@@ -45,12 +49,39 @@ var (
 type HoconValue struct {
 	values   []HoconElement
 	oldValue *HoconValue
+	origin   *HoconOrigin
+	comments []string
 }
 
 func NewHoconValue() *HoconValue {
 	return &HoconValue{}
 }
 
+// Origin returns where the value was defined, nil if unknown.
+func (p *HoconValue) Origin() *HoconOrigin {
+	if p == nil {
+		return nil
+	}
+	return p.origin
+}
+
+func (p *HoconValue) SetOrigin(origin *HoconOrigin) {
+	p.origin = origin
+}
+
+// Comments returns the lines of the comment block written right above the key of
+// the value, without their # or // markers.
+func (p *HoconValue) Comments() []string {
+	if p == nil {
+		return nil
+	}
+	return p.comments
+}
+
+func (p *HoconValue) SetComments(comments []string) {
+	p.comments = comments
+}
+
 func (p *HoconValue) IsEmpty() bool {
 	if p == nil || len(p.values) == 0 {
 		return true
@@ -385,7 +416,7 @@ func (p *HoconValue) GetByte() (byte, error) {
 }
 
 func (p *HoconValue) GetByteList() ([]byte, error) {
-	arrayV, err := p.GetArray()
+	arrayV, err := p.GetList()
 	if err != nil {
 		return nil, err
 	}
@@ -404,7 +435,7 @@ func (p *HoconValue) GetByteList() ([]byte, error) {
 }
 
 func (p *HoconValue) GetInt32List() ([]int32, error) {
-	arrayV, err := p.GetArray()
+	arrayV, err := p.GetList()
 	if err != nil {
 		return nil, err
 	}
@@ -423,7 +454,7 @@ func (p *HoconValue) GetInt32List() ([]int32, error) {
 }
 
 func (p *HoconValue) GetInt64List() ([]int64, error) {
-	arrayV, err := p.GetArray()
+	arrayV, err := p.GetList()
 	if err != nil {
 		return nil, err
 	}
@@ -442,7 +473,7 @@ func (p *HoconValue) GetInt64List() ([]int64, error) {
 }
 
 func (p *HoconValue) GetBooleanList() ([]bool, error) {
-	arrayV, err := p.GetArray()
+	arrayV, err := p.GetList()
 	if err != nil {
 		return nil, err
 	}
@@ -461,7 +492,7 @@ func (p *HoconValue) GetBooleanList() ([]bool, error) {
 }
 
 func (p *HoconValue) GetFloat32List() ([]float32, error) {
-	arrayV, err := p.GetArray()
+	arrayV, err := p.GetList()
 	if err != nil {
 		return nil, err
 	}
@@ -480,7 +511,7 @@ func (p *HoconValue) GetFloat32List() ([]float32, error) {
 }
 
 func (p *HoconValue) GetFloat64List() ([]float64, error) {
-	arrayV, err := p.GetArray()
+	arrayV, err := p.GetList()
 	if err != nil {
 		return nil, err
 	}
@@ -499,7 +530,7 @@ func (p *HoconValue) GetFloat64List() ([]float64, error) {
 }
 
 func (p *HoconValue) GetStringList() ([]string, error) {
-	arrayV, err := p.GetArray()
+	arrayV, err := p.GetList()
 	if err != nil {
 		return nil, err
 	}
@@ -517,6 +548,162 @@ func (p *HoconValue) GetStringList() ([]string, error) {
 	return items, nil
 }
 
+// GetList returns the elements of an array. As the HOCON spec requires, an object
+// whose keys are integers, e.g. parsed from servers.0=a and servers.1=b, is converted
+// to an array ordered by its keys, the other keys being ignored.
+func (p *HoconValue) GetList() ([]*HoconValue, error) {
+	if !p.IsObject() {
+		return p.GetArray()
+	}
+
+	objectV, err := p.GetObject()
+	if err != nil {
+		return nil, err
+	}
+
+	var indices []int
+	values := map[int]*HoconValue{}
+	for _, key := range objectV.GetKeys() {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 {
+			continue
+		}
+
+		if _, exist := values[index]; !exist {
+			indices = append(indices, index)
+		}
+		values[index] = objectV.GetKey(key)
+	}
+
+	if len(indices) == 0 {
+		return nil, errors.New("cannot convert an object without numeric keys to an array")
+	}
+
+	sort.Ints(indices)
+	items := make([]*HoconValue, 0, len(indices))
+	for _, index := range indices {
+		items = append(items, values[index])
+	}
+
+	return items, nil
+}
+
+// Unwrapped returns the value as plain go values: objects become map[string]interface{},
+// arrays []interface{} and the other values a string, an int64, a float64, a bool or
+// nil. An integer too large for an int64 is a json.Number. A quoted value is always a
+// string, e.g. "true" or "10".
+func (p *HoconValue) Unwrapped() (interface{}, error) {
+	if p == nil || len(p.values) == 0 {
+		return nil, nil
+	}
+
+	if len(p.values) == 1 {
+		switch v := p.topValueOfSub(p.values[0]).(type) {
+		case *HoconSubstitution:
+			if v.ResolvedValue == nil {
+				return nil, nil
+			}
+			if err := v.checkCycleRef(); err != nil {
+				return nil, err
+			}
+			return v.ResolvedValue.Unwrapped()
+		case *HoconValue:
+			return v.Unwrapped()
+		}
+	}
+
+	if p.IsObject() {
+		objectV, err := p.GetObject()
+		// must not return error after checking p.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		return objectV.Unwrapped()
+	}
+
+	if p.IsArray() {
+		arrayV, err := p.GetArray()
+		// must not return error after checking p.IsArray()
+		if err != nil {
+			panic(err)
+		}
+
+		items := make([]interface{}, 0, len(arrayV))
+		for _, v := range arrayV {
+			item, err := v.Unwrapped()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	if !p.IsString() {
+		return nil, fmt.Errorf("cannot unwrap value %s", p)
+	}
+
+	return p.unwrappedString()
+}
+
+// unwrappedString types the text of a string value unless a part of it is quoted.
+func (p *HoconValue) unwrappedString() (interface{}, error) {
+	var sb strings.Builder
+	quoted := false
+	for _, v := range p.values {
+		v = p.topValueOfSub(v)
+		if literal, ok := v.(*HoconLiteral); ok && literal.IsQuoted() {
+			quoted = true
+		}
+
+		stringV, err := v.GetString()
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString(stringV)
+	}
+
+	text := strings.TrimSpace(sb.String())
+	if quoted {
+		return text, nil
+	}
+
+	return typedText(text), nil
+}
+
+// typedText returns the null, boolean or number spelled by an unquoted text, or the
+// text itself.
+func typedText(text string) interface{} {
+	switch text {
+	case "null":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if !numberRegexp.MatchString(text) {
+		return text
+	}
+
+	if intV, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return intV
+	}
+
+	if !strings.ContainsAny(text, ".eE") {
+		// an integer too large for an int64 keeps all its digits
+		return json.Number(text)
+	}
+
+	if floatV, err := strconv.ParseFloat(text, 64); err == nil {
+		return floatV
+	}
+
+	return text
+}
+
 func (p *HoconValue) GetArray() ([]*HoconValue, error) {
 	var items []*HoconValue
 	if p == nil {
@@ -542,6 +729,29 @@ func (p *HoconValue) GetArray() ([]*HoconValue, error) {
 	return items, nil
 }
 
+// GetChild returns the value of the key when p is an object, or the element at
+// the index the key holds when p is an array. It returns nil if there is none.
+func (p *HoconValue) GetChild(key string) (*HoconValue, error) {
+	if !p.IsObject() && p.IsArray() {
+		index, err := strconv.ParseUint(key, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get key %s from an array", key)
+		}
+
+		arrayV, err := p.GetArray()
+		if err != nil {
+			return nil, err
+		}
+
+		if index >= uint64(len(arrayV)) {
+			return nil, nil
+		}
+		return arrayV[index], nil
+	}
+
+	return p.GetChildObject(key)
+}
+
 func (p *HoconValue) GetChildObject(key string) (*HoconValue, error) {
 	objectV, err := p.GetObject()
 	if err != nil {
@@ -551,13 +761,38 @@ func (p *HoconValue) GetChildObject(key string) (*HoconValue, error) {
 	return objectV.GetKey(key), nil
 }
 
+// GetNode returns the value addressed by the path, nil if it cannot be found.
+func (p *HoconValue) GetNode(path Path) (*HoconValue, error) {
+	if p == nil {
+		return nil, errors.New("current node should not be null")
+	}
+
+	currentNode := p
+	for _, key := range path {
+		var err error
+		currentNode, err = currentNode.GetChild(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if currentNode == nil {
+			return nil, nil
+		}
+	}
+	return currentNode, nil
+}
+
 func (p *HoconValue) IsArray() bool {
-	arr, err := p.GetArray()
-	if err != nil {
+	if p == nil {
 		return false
 	}
 
-	return arr != nil
+	for _, v := range p.values {
+		if p.topValueOfSub(v).IsArray() {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *HoconValue) GetTimeDuration(allowInfinite bool) (time.Duration, error) {
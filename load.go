@@ -0,0 +1,150 @@
+package configuration
+
+import (
+	"os"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+const (
+	defaultReferenceFile      = "reference.conf"
+	defaultApplicationFile    = "application.conf"
+	defaultApplicationFileEnv = "CONFIG_FILE"
+)
+
+// LoadOptions describes the layers merged by Load.
+type LoadOptions struct {
+	// ReferenceFiles hold the default settings, the first file wins. Missing files are
+	// skipped. reference.conf is used if nil.
+	ReferenceFiles []string
+	// ApplicationFile is used when the environment does not name one, application.conf
+	// if empty. It is skipped when missing.
+	ApplicationFile string
+	// ApplicationFileEnv names the environment variable selecting the application file,
+	// CONFIG_FILE if empty. A file named by the environment must exist.
+	ApplicationFileEnv string
+	// Overrides take precedence over the application file, the first one wins.
+	Overrides []*Config
+	// EnvOverrides layers the CONFIG_FORCE_ environment variables above everything else.
+	EnvOverrides bool
+	// OnReferenceConflict is called for every setting defined by two registered references.
+	OnReferenceConflict func(conflict ReferenceConflict)
+	// IncludeCallback loads included files, the default one reads them from disk.
+	IncludeCallback hocon.IncludeCallback
+}
+
+// Load merges, from the highest precedence to the lowest, the environment overrides,
+// the overrides, the application file, the reference files and the references
+// registered with RegisterReference, then resolves the substitutions once against
+// the merged tree, so that the application file may refer to the reference settings
+// and the other way round.
+func Load(opts ...LoadOptions) (*Config, error) {
+	var opt LoadOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	callback := opt.IncludeCallback
+	if callback == nil {
+		callback = defaultIncludeCallback
+	}
+
+	var layers []*Config
+
+	if opt.EnvOverrides {
+		envConfig, err := EnvOverrides()
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, envConfig)
+	}
+
+	layers = append(layers, opt.Overrides...)
+
+	applicationFile, required := opt.ApplicationFile, false
+	if len(applicationFile) == 0 {
+		applicationFile = defaultApplicationFile
+	}
+
+	applicationFileEnv := opt.ApplicationFileEnv
+	if len(applicationFileEnv) == 0 {
+		applicationFileEnv = defaultApplicationFileEnv
+	}
+
+	if filename, exist := os.LookupEnv(applicationFileEnv); exist && len(filename) > 0 {
+		applicationFile, required = filename, true
+	}
+
+	application, err := parseFile(applicationFile, !required, false, callback)
+	if err != nil {
+		return nil, err
+	}
+	layers = append(layers, application)
+
+	referenceFiles := opt.ReferenceFiles
+	if referenceFiles == nil {
+		referenceFiles = []string{defaultReferenceFile}
+	}
+
+	for _, filename := range referenceFiles {
+		reference, err := parseFile(filename, true, false, callback)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, reference)
+	}
+
+	registered, conflicts, err := parseReferences(callback)
+	if err != nil {
+		return nil, err
+	}
+	layers = append(layers, registered...)
+
+	if opt.OnReferenceConflict != nil {
+		for _, conflict := range conflicts {
+			opt.OnReferenceConflict(conflict)
+		}
+	}
+
+	return mergeAndResolve(layers)
+}
+
+// mergeAndResolve merges the layers, the first one wins, and resolves the
+// substitutions of the unresolved layers against the result, but for those referring
+// to their own path, e.g. path = ${path}":/x", which get the value of the path in the
+// layers below. The substitutions of the other layers are left untouched, as they may
+// be shared with other configs.
+func mergeAndResolve(layers []*Config) (*Config, error) {
+	merged, err := newConfigFromObject(hocon.NewHoconObject())
+	if err != nil {
+		return nil, err
+	}
+
+	var substitutions []*hocon.HoconSubstitution
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+		if layer == nil || layer.root == nil {
+			continue
+		}
+
+		if layer.unresolved {
+			// merged holds the layers below at this point
+			others, err := hocon.ResolveSelfReferences(layer.root, merged.root, layer.substitutions)
+			if err != nil {
+				return nil, err
+			}
+			substitutions = append(substitutions, others...)
+		}
+
+		merged, err = (&Config{root: layer.root}).WithFallback(merged)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := hocon.ResolveSubstitutions(merged.root, substitutions); err != nil {
+		return nil, err
+	}
+
+	return &Config{root: merged.root, substitutions: substitutions}, nil
+}
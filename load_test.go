@@ -0,0 +1,145 @@
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFile(t *testing.T, dir, name, text string) string {
+	filename := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go_hocon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	reference := writeTestFile(t, dir, "reference.conf", `
+		app { name = reference, port = 80, url = "http://"${app.host}":"${app.port} }
+	`)
+	application := writeTestFile(t, dir, "application.conf", `
+		app { host = localhost, port = 8080 }
+	`)
+
+	args, _, err := ParseArgs([]string{"-Dapp.host=example.com"})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conf, err := Load(LoadOptions{
+		ReferenceFiles:     []string{reference, filepath.Join(dir, "missing.conf")},
+		ApplicationFile:    application,
+		ApplicationFileEnv: "GO_HOCON_TEST_CONFIG_FILE",
+		Overrides:          []*Config{args},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	name, err := conf.GetString("app.name")
+	assert.Nil(t, err)
+	assert.Equal(t, "reference", name)
+
+	url, err := conf.GetString("app.url")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://example.com:8080", url)
+}
+
+func TestLoad_ApplicationFileFromEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go_hocon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	application := writeTestFile(t, dir, "production.conf", `app.port = 443`)
+
+	os.Setenv("GO_HOCON_TEST_CONFIG_FILE", application)
+	defer os.Unsetenv("GO_HOCON_TEST_CONFIG_FILE")
+
+	conf, err := Load(LoadOptions{
+		ReferenceFiles:     []string{},
+		ApplicationFileEnv: "GO_HOCON_TEST_CONFIG_FILE",
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	port, err := conf.GetInt32("app.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(443), port)
+
+	os.Setenv("GO_HOCON_TEST_CONFIG_FILE", filepath.Join(dir, "missing.conf"))
+	_, err = Load(LoadOptions{ApplicationFileEnv: "GO_HOCON_TEST_CONFIG_FILE"})
+	assert.NotNil(t, err)
+}
+
+func TestLoad_SelfReferencesAcrossLayers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go_hocon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	reference := writeTestFile(t, dir, "reference.conf", `
+		path = "/usr"
+		list = [1, 2]
+		app { dirs = [a] }
+		twice = x
+		twice = ${twice}y
+	`)
+	application := writeTestFile(t, dir, "application.conf", `
+		path = ${path}":/x"
+		list = ${list} [3]
+		app { dirs = ${app.dirs} [b] }
+		twice = ${twice}z
+		local = 1
+		local = ${local}2
+	`)
+
+	conf, err := Load(LoadOptions{
+		ReferenceFiles:     []string{reference},
+		ApplicationFile:    application,
+		ApplicationFileEnv: "GO_HOCON_TEST_CONFIG_FILE",
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if path, err := conf.GetString("path"); assert.Nil(t, err) {
+		assert.Equal(t, "/usr:/x", path)
+	}
+
+	if list, err := conf.GetInt32List("list"); assert.Nil(t, err) {
+		assert.Equal(t, []int32{1, 2, 3}, list)
+	}
+
+	if dirs, err := conf.GetStringList("app.dirs"); assert.Nil(t, err) {
+		assert.Equal(t, []string{"a", "b"}, dirs)
+	}
+
+	if twice, err := conf.GetString("twice"); assert.Nil(t, err) {
+		assert.Equal(t, "xyz", twice)
+	}
+
+	if local, err := conf.GetString("local"); assert.Nil(t, err) {
+		assert.Equal(t, "12", local)
+	}
+
+	_, err = Load(LoadOptions{
+		ReferenceFiles:     []string{},
+		ApplicationFile:    application,
+		ApplicationFileEnv: "GO_HOCON_TEST_CONFIG_FILE",
+	})
+	assert.NotNil(t, err)
+}
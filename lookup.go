@@ -0,0 +1,222 @@
+package configuration
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// getValue converts the value at the path, or returns the first default value when
+// the path is missing.
+func getValue[T any](p *Config, path string, convert func(*hocon.HoconValue) (T, error), defaultVal []T) (T, error) {
+	node, err := p.GetNode(path)
+	if err != nil {
+		if len(defaultVal) > 0 && errors.Is(err, ErrMissingPath) {
+			return defaultVal[0], nil
+		}
+
+		var zero T
+		return zero, err
+	}
+
+	return convert(node)
+}
+
+// lookupValue converts the value at the path and tells whether the path is set, a
+// missing path is not an error.
+func lookupValue[T any](p *Config, path string, convert func(*hocon.HoconValue) (T, error)) (T, bool, error) {
+	var zero T
+
+	node, err := p.GetNode(path)
+	if errors.Is(err, ErrMissingPath) {
+		return zero, false, nil
+	}
+	if err != nil {
+		return zero, false, err
+	}
+
+	value, err := convert(node)
+	if err != nil {
+		return zero, true, err
+	}
+	return value, true, nil
+}
+
+func mustValue[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func durationInfiniteAllowed(value *hocon.HoconValue) (time.Duration, error) {
+	return value.GetTimeDuration(true)
+}
+
+func durationInfiniteNotAllowed(value *hocon.HoconValue) (time.Duration, error) {
+	return value.GetTimeDuration(false)
+}
+
+func (p *Config) LookupBoolean(path string) (bool, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetBoolean)
+}
+
+func (p *Config) LookupByteSize(path string) (*big.Int, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetByteSize)
+}
+
+func (p *Config) LookupInt32(path string) (int32, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetInt32)
+}
+
+func (p *Config) LookupInt64(path string) (int64, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetInt64)
+}
+
+func (p *Config) LookupString(path string) (string, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetString)
+}
+
+func (p *Config) LookupFloat32(path string) (float32, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetFloat32)
+}
+
+func (p *Config) LookupFloat64(path string) (float64, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetFloat64)
+}
+
+func (p *Config) LookupTimeDuration(path string) (time.Duration, bool, error) {
+	return lookupValue(p, path, durationInfiniteAllowed)
+}
+
+func (p *Config) LookupTimeDurationInfiniteNotAllowed(path string) (time.Duration, bool, error) {
+	return lookupValue(p, path, durationInfiniteNotAllowed)
+}
+
+func (p *Config) LookupBooleanList(path string) ([]bool, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetBooleanList)
+}
+
+func (p *Config) LookupFloat32List(path string) ([]float32, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetFloat32List)
+}
+
+func (p *Config) LookupFloat64List(path string) ([]float64, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetFloat64List)
+}
+
+func (p *Config) LookupInt32List(path string) ([]int32, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetInt32List)
+}
+
+func (p *Config) LookupInt64List(path string) ([]int64, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetInt64List)
+}
+
+func (p *Config) LookupByteList(path string) ([]byte, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetByteList)
+}
+
+func (p *Config) LookupStringList(path string) ([]string, bool, error) {
+	return lookupValue(p, path, (*hocon.HoconValue).GetStringList)
+}
+
+func (p *Config) LookupConfig(path string) (*Config, bool, error) {
+	config, err := p.GetConfig(path)
+	if errors.Is(err, ErrMissingPath) {
+		return nil, false, nil
+	}
+	return config, err == nil, err
+}
+
+func (p *Config) LookupConfigList(path string) ([]*Config, bool, error) {
+	configs, err := p.GetConfigList(path)
+	if errors.Is(err, ErrMissingPath) {
+		return nil, false, nil
+	}
+	return configs, err == nil, err
+}
+
+func (p *Config) LookupValue(path string) (*hocon.HoconValue, bool, error) {
+	return lookupValue(p, path, func(value *hocon.HoconValue) (*hocon.HoconValue, error) {
+		return value, nil
+	})
+}
+
+func (p *Config) MustBoolean(path string) bool {
+	return mustValue(p.GetBoolean(path))
+}
+
+func (p *Config) MustByteSize(path string) *big.Int {
+	return mustValue(p.GetByteSize(path))
+}
+
+func (p *Config) MustInt32(path string) int32 {
+	return mustValue(p.GetInt32(path))
+}
+
+func (p *Config) MustInt64(path string) int64 {
+	return mustValue(p.GetInt64(path))
+}
+
+func (p *Config) MustString(path string) string {
+	return mustValue(p.GetString(path))
+}
+
+func (p *Config) MustFloat32(path string) float32 {
+	return mustValue(p.GetFloat32(path))
+}
+
+func (p *Config) MustFloat64(path string) float64 {
+	return mustValue(p.GetFloat64(path))
+}
+
+func (p *Config) MustTimeDuration(path string) time.Duration {
+	return mustValue(p.GetTimeDuration(path))
+}
+
+func (p *Config) MustTimeDurationInfiniteNotAllowed(path string) time.Duration {
+	return mustValue(p.GetTimeDurationInfiniteNotAllowed(path))
+}
+
+func (p *Config) MustBooleanList(path string) []bool {
+	return mustValue(p.GetBooleanList(path))
+}
+
+func (p *Config) MustFloat32List(path string) []float32 {
+	return mustValue(p.GetFloat32List(path))
+}
+
+func (p *Config) MustFloat64List(path string) []float64 {
+	return mustValue(p.GetFloat64List(path))
+}
+
+func (p *Config) MustInt32List(path string) []int32 {
+	return mustValue(p.GetInt32List(path))
+}
+
+func (p *Config) MustInt64List(path string) []int64 {
+	return mustValue(p.GetInt64List(path))
+}
+
+func (p *Config) MustByteList(path string) []byte {
+	return mustValue(p.GetByteList(path))
+}
+
+func (p *Config) MustStringList(path string) []string {
+	return mustValue(p.GetStringList(path))
+}
+
+func (p *Config) MustConfig(path string) *Config {
+	return mustValue(p.GetConfig(path))
+}
+
+func (p *Config) MustConfigList(path string) []*Config {
+	return mustValue(p.GetConfigList(path))
+}
+
+func (p *Config) MustValue(path string) *hocon.HoconValue {
+	return mustValue(p.GetValue(path))
+}
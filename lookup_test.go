@@ -0,0 +1,179 @@
+package configuration
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lookupConfig = `
+zero = 0
+empty = ""
+name = demo
+timeout = 0s
+ports = [80, 443]
+http { port = 8080 }
+`
+
+func TestConfig_MissingPath(t *testing.T) {
+	conf, err := ParseString(lookupConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	for _, path := range []string{"missing", "http.missing", "missing.port", "ports.5"} {
+		_, err := conf.GetInt64(path)
+		assert.True(t, errors.Is(err, ErrMissingPath), path)
+
+		_, err = conf.GetString(path)
+		assert.True(t, errors.Is(err, ErrMissingPath), path)
+
+		_, err = conf.GetInt64List(path)
+		assert.True(t, errors.Is(err, ErrMissingPath), path)
+
+		_, err = conf.GetConfig(path)
+		assert.True(t, errors.Is(err, ErrMissingPath), path)
+	}
+
+	if value, err := conf.GetInt64("zero"); assert.Nil(t, err) {
+		assert.Equal(t, int64(0), value)
+	}
+
+	for _, path := range []string{"name.first", "zero.x", "ports.x"} {
+		_, err = conf.GetInt64(path)
+		assert.True(t, errors.Is(err, ErrMissingPath), path)
+	}
+
+	_, err = (*Config)(nil).GetString("a")
+	assert.True(t, errors.Is(err, ErrMissingPath))
+
+	_, err = (&Config{}).GetString("a")
+	assert.True(t, errors.Is(err, ErrMissingPath))
+}
+
+func TestConfig_GettersDefaultOnlyWhenMissing(t *testing.T) {
+	conf, err := ParseString(lookupConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, err := conf.GetInt64("missing", 7); assert.Nil(t, err) {
+		assert.Equal(t, int64(7), value)
+	}
+
+	if value, err := conf.GetInt64("zero", 7); assert.Nil(t, err) {
+		assert.Equal(t, int64(0), value)
+	}
+
+	if value, err := conf.GetString("missing", "def"); assert.Nil(t, err) {
+		assert.Equal(t, "def", value)
+	}
+
+	if value, err := conf.GetString("empty", "def"); assert.Nil(t, err) {
+		assert.Equal(t, "", value)
+	}
+
+	if value, err := conf.GetTimeDuration("missing", time.Second); assert.Nil(t, err) {
+		assert.Equal(t, time.Second, value)
+	}
+
+	if value, err := conf.GetTimeDuration("timeout", time.Second); assert.Nil(t, err) {
+		assert.Equal(t, time.Duration(0), value)
+	}
+
+	if value, err := conf.GetByteSize("missing", big.NewInt(1024)); assert.Nil(t, err) {
+		assert.Equal(t, big.NewInt(1024), value)
+	}
+
+	_, err = conf.GetInt64("name", 7)
+	assert.NotNil(t, err)
+
+	if value, err := conf.GetString("zero.x", "def"); assert.Nil(t, err) {
+		assert.Equal(t, "def", value)
+	}
+
+	fallback, err := ParseString("missing = 3")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	merged, err := conf.WithFallback(fallback)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, err := merged.GetInt64("missing", 7); assert.Nil(t, err) {
+		assert.Equal(t, int64(3), value)
+	}
+}
+
+func TestConfig_Lookup(t *testing.T) {
+	conf, err := ParseString(lookupConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if value, found, err := conf.LookupInt64("zero"); assert.Nil(t, err) {
+		assert.True(t, found)
+		assert.Equal(t, int64(0), value)
+	}
+
+	if value, found, err := conf.LookupInt64("missing"); assert.Nil(t, err) {
+		assert.False(t, found)
+		assert.Equal(t, int64(0), value)
+	}
+
+	if value, found, err := conf.LookupString("empty"); assert.Nil(t, err) {
+		assert.True(t, found)
+		assert.Equal(t, "", value)
+	}
+
+	if value, found, err := conf.LookupInt32List("ports"); assert.Nil(t, err) {
+		assert.True(t, found)
+		assert.Equal(t, []int32{80, 443}, value)
+	}
+
+	if value, found, err := conf.LookupConfig("http"); assert.Nil(t, err) {
+		assert.True(t, found)
+		assert.Equal(t, int64(8080), value.MustInt64("port"))
+	}
+
+	if _, found, err := conf.LookupString("zero.x"); assert.Nil(t, err) {
+		assert.False(t, found)
+	}
+
+	if _, found, err := conf.LookupConfig("missing"); assert.Nil(t, err) {
+		assert.False(t, found)
+	}
+
+	_, found, err := conf.LookupInt64("name")
+	assert.NotNil(t, err)
+	assert.True(t, found)
+
+	if value, found, err := Lookup[uint16](conf, "http.port"); assert.Nil(t, err) {
+		assert.True(t, found)
+		assert.Equal(t, uint16(8080), value)
+	}
+
+	if _, found, err := Lookup[uint16](conf, "http.missing"); assert.Nil(t, err) {
+		assert.False(t, found)
+	}
+}
+
+func TestConfig_Must(t *testing.T) {
+	conf, err := ParseString(lookupConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "demo", conf.MustString("name"))
+	assert.Equal(t, []int64{80, 443}, conf.MustInt64List("ports"))
+	assert.Equal(t, 8080, Must[int](conf, "http.port"))
+
+	assert.Panics(t, func() { conf.MustString("missing") })
+	assert.Panics(t, func() { conf.MustInt32("name") })
+	assert.Panics(t, func() { Must[int](conf, "missing") })
+}
@@ -0,0 +1,164 @@
+package configuration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// ParseProperties parses text in the java .properties format. Dotted keys become
+// nested objects and every value is a string. When a key is both a value and an
+// object, e.g. a=1 and a.b=2, the object wins.
+func ParseProperties(text string, origin ...string) (*Config, error) {
+	var description string
+	if len(origin) > 0 {
+		description = origin[0]
+	}
+
+	root := hocon.NewHoconObject()
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if len(line) == 0 || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		key, value, err := splitProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %d: %s", description, lineNumber, err)
+		}
+
+		if len(key) == 0 {
+			continue
+		}
+
+		// like java properties, keys are split on every dot and never unquoted
+		keys := hocon.PathOf(strings.Split(key, ".")...)
+
+		if existing := getChild(root, keys); existing != nil && existing.IsObject() {
+			continue
+		}
+
+		var valueOrigin *hocon.HoconOrigin
+		if len(description) > 0 {
+			valueOrigin = hocon.NewHoconOrigin(description, lineNumber)
+		}
+		setPathString(root, keys, value, valueOrigin)
+	}
+
+	return newConfigFromObject(root)
+}
+
+// endsWithContinuation checks whether the line ends with an odd number of backslashes.
+func endsWithContinuation(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// splitProperty splits a logical line into its unescaped key and value.
+func splitProperty(line string) (string, string, error) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+
+		if strings.IndexByte("=: \t\f", line[i]) >= 0 {
+			end = i
+			break
+		}
+	}
+
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if len(rest) > 0 && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	key, err := unescapeProperty(line[:end])
+	if err != nil {
+		return "", "", err
+	}
+
+	value, err := unescapeProperty(rest)
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
+
+func unescapeProperty(text string) (string, error) {
+	if strings.IndexByte(text, '\\') < 0 {
+		return text, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c != '\\' || i+1 >= len(text) {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch text[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(text) {
+				return "", fmt.Errorf("malformed \\u escape in %q", text)
+			}
+
+			code, err := strconv.ParseUint(text[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\u escape in %q", text)
+			}
+			sb.WriteRune(rune(code))
+			i += 4
+		default:
+			sb.WriteByte(text[i])
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// getChild returns the value under the given keys, nil if there is none.
+func getChild(obj *hocon.HoconObject, keys hocon.Path) *hocon.HoconValue {
+	var value *hocon.HoconValue
+	for _, key := range keys {
+		if obj == nil {
+			return nil
+		}
+
+		value = obj.GetKey(key)
+		if value == nil {
+			return nil
+		}
+
+		obj = nil
+		if value.IsObject() {
+			obj, _ = value.GetObject()
+		}
+	}
+	return value
+}
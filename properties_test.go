@@ -0,0 +1,67 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProperties(t *testing.T) {
+	conf, err := ParseProperties(`
+# comment
+! another comment
+akka.loglevel = DEBUG
+akka.actor.timeout: 5s
+server.name    my\ server
+server.path=/a/b\
+    /c
+server.unicode=été
+servers.0=a
+servers=ignored
+`, "app.properties")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	for path, want := range map[string]string{
+		"akka.loglevel":      "DEBUG",
+		"akka.actor.timeout": "5s",
+		"server.name":        "my server",
+		"server.path":        "/a/b/c",
+		"server.unicode":     "été",
+	} {
+		got, err := conf.GetString(path)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got, path)
+	}
+
+	assert.True(t, conf.IsObject("servers"))
+
+	value, err := conf.GetValue("akka.actor.timeout")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "app.properties: 5", value.Origin().String())
+	}
+}
+
+func TestParseProperties_KeepsStrings(t *testing.T) {
+	conf, err := ParseProperties("app.version=1.10\napp.enabled=true\n")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	unwrapped, err := conf.Unwrapped()
+	if assert.Nil(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"app": map[string]interface{}{"version": "1.10", "enabled": "true"},
+		}, unwrapped)
+	}
+
+	enabled, err := conf.GetBoolean("app.enabled")
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+}
+
+func TestParseProperties_MalformedEscape(t *testing.T) {
+	_, err := ParseProperties(`a=\u00`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,121 @@
+package configuration
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+const (
+	queryAnyDepth  = "**"
+	queryRegexpTag = "~"
+)
+
+// QueryMatch is a value found by Query.
+type QueryMatch struct {
+	Path   hocon.Path
+	Value  *hocon.HoconValue
+	Origin *hocon.HoconOrigin
+}
+
+// queryMatcher matches a single key of a query pattern, a nil matcher stands for **.
+type queryMatcher func(key string) bool
+
+// Query returns the values whose path matches the pattern, in document order.
+// The pattern is a path expression where a * key matches any single key and a **
+// key matches any number of keys, including none. Inside a key, * matches any text
+// and ? a single character, e.g. akka.actor.deployment."/user/*". A key starting
+// with ~ is a regular expression, e.g. akka."~-dispatcher$".timeout. Array elements
+// are matched by their index.
+func (p *Config) Query(pattern string) ([]QueryMatch, error) {
+	segments, err := hocon.ParsePath(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := make([]queryMatcher, len(segments))
+	for i, segment := range segments {
+		matchers[i], err = compileQuerySegment(segment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.IsEmpty() {
+		return nil, nil
+	}
+
+	var matches []QueryMatch
+	seen := map[string]bool{}
+	queryValue(p.root, hocon.Path{}, matchers, func(path hocon.Path, value *hocon.HoconValue) {
+		rendered := path.Render()
+		if seen[rendered] {
+			return
+		}
+		seen[rendered] = true
+		matches = append(matches, QueryMatch{Path: path, Value: value, Origin: value.Origin()})
+	})
+
+	return matches, nil
+}
+
+func compileQuerySegment(segment string) (queryMatcher, error) {
+	if segment == queryAnyDepth {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(segment, queryRegexpTag) {
+		reg, err := regexp.Compile(strings.TrimPrefix(segment, queryRegexpTag))
+		if err != nil {
+			return nil, fmt.Errorf("invalid query key %q: %s", segment, err)
+		}
+		return reg.MatchString, nil
+	}
+
+	if !strings.ContainsAny(segment, "*?") {
+		return func(key string) bool { return key == segment }, nil
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range segment {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	reg := regexp.MustCompile(expr.String())
+	return reg.MatchString, nil
+}
+
+func queryValue(value *hocon.HoconValue, path hocon.Path, matchers []queryMatcher, emit func(hocon.Path, *hocon.HoconValue)) {
+	if len(matchers) == 0 {
+		emit(path, value)
+		return
+	}
+
+	matcher := matchers[0]
+	if matcher == nil {
+		queryValue(value, path, matchers[1:], emit)
+	}
+
+	keys, children := childrenOf(value)
+	for i, key := range keys {
+		if matcher == nil {
+			queryValue(children[i], path.Child(key), matchers, emit)
+			continue
+		}
+
+		if matcher(key) {
+			queryValue(children[i], path.Child(key), matchers[1:], emit)
+		}
+	}
+}
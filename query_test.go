@@ -0,0 +1,76 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func queryPaths(t *testing.T, conf *Config, pattern string) []string {
+	matches, err := conf.Query(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, match := range matches {
+		paths = append(paths, match.Path.Render())
+	}
+	return paths
+}
+
+func TestConfig_Query(t *testing.T) {
+	conf, err := ParseString(`
+akka {
+  default-dispatcher { timeout = 1s, throughput = 5 }
+  io-dispatcher { timeout = 2s }
+  actor {
+    deployment {
+      "/user/a" { router = round-robin }
+      "/user/b/c" { router = random }
+      "/system/d" { router = random }
+    }
+    timeout = 3s
+  }
+  servers = [{ timeout = 4s }]
+}
+`)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{
+		"akka.default-dispatcher.timeout",
+		"akka.io-dispatcher.timeout",
+		"akka.actor.timeout",
+	}, queryPaths(t, conf, "akka.*.timeout"))
+
+	assert.Equal(t, []string{
+		"akka.default-dispatcher.timeout",
+		"akka.io-dispatcher.timeout",
+		"akka.actor.timeout",
+		"akka.servers.0.timeout",
+	}, queryPaths(t, conf, "**.timeout"))
+
+	assert.Equal(t, []string{
+		"akka.actor.deployment./user/a",
+		"akka.actor.deployment./user/b/c",
+	}, queryPaths(t, conf, `akka.actor.deployment."/user/*"`))
+
+	assert.Equal(t, []string{
+		"akka.default-dispatcher.timeout",
+		"akka.io-dispatcher.timeout",
+	}, queryPaths(t, conf, `akka."~-dispatcher$".timeout`))
+
+	assert.Equal(t, []string{"akka.servers.0.timeout"}, queryPaths(t, conf, "akka.servers.*.timeout"))
+	assert.Equal(t, []string{"akka.actor"}, queryPaths(t, conf, "akka.act?r"))
+	assert.Empty(t, queryPaths(t, conf, "missing.**"))
+
+	matches, err := conf.Query("akka.io-dispatcher.timeout")
+	if assert.Nil(t, err) && assert.Len(t, matches, 1) {
+		assert.Equal(t, "2s", matches[0].Value.String())
+	}
+
+	_, err = conf.Query(`akka."~("`)
+	assert.NotNil(t, err)
+}
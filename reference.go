@@ -0,0 +1,89 @@
+package configuration
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+type registeredReference struct {
+	name string
+	text string
+}
+
+var (
+	referencesLock sync.Mutex
+	references     []registeredReference
+)
+
+// ReferenceConflict reports a setting defined by the reference configs of two
+// libraries. The one registered first wins.
+type ReferenceConflict struct {
+	Path   string
+	First  string
+	Second string
+}
+
+func (p ReferenceConflict) String() string {
+	return fmt.Sprintf("%s is defined by both %s and %s", p.Path, p.First, p.Second)
+}
+
+// RegisterReference registers the default settings of a library, usually from an
+// init function. Load merges every registered reference below the application config.
+// It panics if the name is registered twice or if the text cannot be parsed.
+func RegisterReference(name, text string) {
+	if _, err := hocon.ParseUnresolved(text, defaultIncludeCallback); err != nil {
+		panic(fmt.Sprintf("configuration: cannot parse reference %s: %s", name, err))
+	}
+
+	referencesLock.Lock()
+	defer referencesLock.Unlock()
+
+	for _, ref := range references {
+		if ref.name == name {
+			panic("configuration: reference registered twice: " + name)
+		}
+	}
+
+	references = append(references, registeredReference{name: name, text: text})
+}
+
+// parseReferences parses the registered references in registration order and finds
+// the leaf paths defined more than once.
+func parseReferences(callback hocon.IncludeCallback) ([]*Config, []ReferenceConflict, error) {
+	referencesLock.Lock()
+	registered := make([]registeredReference, len(references))
+	copy(registered, references)
+	referencesLock.Unlock()
+
+	var layers []*Config
+	var conflicts []ReferenceConflict
+	definedBy := map[string]string{}
+
+	for _, ref := range registered {
+		root, err := hocon.ParseUnresolved(ref.text, callback)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		config, err := NewConfigFromRoot(root)
+		if err != nil {
+			return nil, nil, err
+		}
+		config.unresolved = true
+
+		for _, entry := range config.Entries() {
+			path := entry.Path.Render()
+			if first, exist := definedBy[path]; exist {
+				conflicts = append(conflicts, ReferenceConflict{Path: path, First: first, Second: ref.name})
+				continue
+			}
+			definedBy[path] = ref.name
+		}
+
+		layers = append(layers, config)
+	}
+
+	return layers, conflicts, nil
+}
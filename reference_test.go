@@ -0,0 +1,62 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetReferences() {
+	referencesLock.Lock()
+	references = nil
+	referencesLock.Unlock()
+}
+
+func TestRegisterReference(t *testing.T) {
+	resetReferences()
+	defer resetReferences()
+
+	RegisterReference("http", `http { port = 80, timeout = 1s, url = "http://"${app.host} }`)
+	RegisterReference("grpc", `grpc.port = 9090, http.timeout = 5s`)
+
+	assert.Panics(t, func() { RegisterReference("http", `http.port = 81`) })
+	assert.Panics(t, func() { RegisterReference("broken", `http.port =`) })
+
+	var conflicts []ReferenceConflict
+	conf, err := Load(LoadOptions{
+		ReferenceFiles: []string{},
+		Overrides:      []*Config{mustParse(t, `app.host = example.com, http.port = 8080`)},
+		OnReferenceConflict: func(conflict ReferenceConflict) {
+			conflicts = append(conflicts, conflict)
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, []ReferenceConflict{{Path: "http.timeout", First: "http", Second: "grpc"}}, conflicts)
+
+	port, err := conf.GetInt32("http.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8080), port)
+
+	timeout, err := conf.GetString("http.timeout")
+	assert.Nil(t, err)
+	assert.Equal(t, "1s", timeout)
+
+	grpcPort, err := conf.GetInt32("grpc.port")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(9090), grpcPort)
+
+	url, err := conf.GetString("http.url")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://example.com", url)
+}
+
+func mustParse(t *testing.T, text string) *Config {
+	conf, err := ParseString(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conf
+}
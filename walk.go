@@ -0,0 +1,137 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/goreflect/go_hocon/hocon"
+)
+
+// SkipChildren is returned by a WalkFunc to skip the children of the current value.
+var SkipChildren = errors.New("skip children")
+
+// WalkFunc is called by Walk for every value with its full path.
+type WalkFunc func(path hocon.Path, value *hocon.HoconValue) error
+
+// Entry is a leaf value of a config with its full path.
+type Entry struct {
+	Path  hocon.Path
+	Value *hocon.HoconValue
+}
+
+// Keys returns the keys of the object at the given path, the root object if the path is empty.
+// An empty config has no keys.
+func (p *Config) Keys(path string) ([]string, error) {
+	if p.IsEmpty() && len(path) == 0 {
+		return []string{}, nil
+	}
+
+	var value *hocon.HoconValue
+	if len(path) > 0 {
+		var err error
+		value, err = p.GetNode(path)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		value = p.Root()
+	}
+
+	obj, err := value.GetObject()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get keys of %s: %s", path, err)
+	}
+
+	keys := make([]string, len(obj.GetKeys()))
+	copy(keys, obj.GetKeys())
+	return keys, nil
+}
+
+// Entries returns every value that is not an object, arrays included, with its full
+// path in document order.
+func (p *Config) Entries() []Entry {
+	var entries []Entry
+	if p.IsEmpty() {
+		return entries
+	}
+
+	walkValue(p.root, hocon.Path{}, func(path hocon.Path, value *hocon.HoconValue) error {
+		if value.IsObject() {
+			return nil
+		}
+
+		entries = append(entries, Entry{Path: path, Value: value})
+		return SkipChildren
+	})
+
+	return entries
+}
+
+// Walk calls fn for every object, array and leaf of the config in document order,
+// parents before their children. Array elements are keyed by their index. Returning
+// SkipChildren from fn skips the children of the value, any other error stops the
+// walk and is returned.
+func (p *Config) Walk(fn WalkFunc) error {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	return walkValue(p.root, hocon.Path{}, fn)
+}
+
+// walkValue walks the value and its children, fn is not called for the root path.
+func walkValue(value *hocon.HoconValue, path hocon.Path, fn WalkFunc) error {
+	if len(path) > 0 {
+		if err := fn(path, value); err != nil {
+			if err == SkipChildren {
+				return nil
+			}
+			return err
+		}
+	}
+
+	keys, children := childrenOf(value)
+	for i, key := range keys {
+		if err := walkValue(children[i], path.Child(key), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// childrenOf returns the keys and values of an object, or the indices and elements
+// of an array. Other values have no children.
+func childrenOf(value *hocon.HoconValue) ([]string, []*hocon.HoconValue) {
+	if value.IsObject() {
+		obj, err := value.GetObject()
+		// must not return error after checking value.IsObject()
+		if err != nil {
+			panic(err)
+		}
+
+		keys := obj.GetKeys()
+		children := make([]*hocon.HoconValue, len(keys))
+		for i, key := range keys {
+			children[i] = obj.GetKey(key)
+		}
+		return keys, children
+	}
+
+	if value.IsArray() {
+		children, err := value.GetArray()
+		// must not return error after checking value.IsArray()
+		if err != nil {
+			panic(err)
+		}
+
+		keys := make([]string, len(children))
+		for i := range children {
+			keys[i] = strconv.Itoa(i)
+		}
+		return keys, children
+	}
+
+	return nil, nil
+}
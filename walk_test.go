@@ -0,0 +1,103 @@
+package configuration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goreflect/go_hocon/hocon"
+	"github.com/stretchr/testify/assert"
+)
+
+const walkTestConfig = `
+akka {
+  loglevel = INFO
+  actor { timeout = 1s, "a.b" = c }
+  loggers = [x, { name = y }]
+}
+version = 1
+`
+
+func TestConfig_Keys(t *testing.T) {
+	conf, err := ParseString(walkTestConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	keys, err := conf.Keys("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"akka", "version"}, keys)
+
+	keys, err = conf.Keys("akka.actor")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"timeout", "a.b"}, keys)
+
+	_, err = conf.Keys("version")
+	assert.NotNil(t, err)
+
+	_, err = conf.Keys("missing")
+	assert.NotNil(t, err)
+
+	keys, err = (*Config)(nil).Keys("")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+
+	_, err = (*Config)(nil).Keys("akka")
+	assert.True(t, errors.Is(err, ErrMissingPath))
+
+	keys, err = (&Config{}).Keys("")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestConfig_Entries(t *testing.T) {
+	conf, err := ParseString(walkTestConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var paths, values []string
+	for _, entry := range conf.Entries() {
+		paths = append(paths, entry.Path.Render())
+		values = append(values, entry.Value.String())
+	}
+
+	assert.Equal(t, []string{"akka.loglevel", "akka.actor.timeout", `akka.actor."a.b"`, "akka.loggers", "version"}, paths)
+	assert.Equal(t, "INFO", values[0])
+	assert.Equal(t, "1", values[4])
+}
+
+func TestConfig_Walk(t *testing.T) {
+	conf, err := ParseString(walkTestConfig)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var paths []string
+	err = conf.Walk(func(path hocon.Path, value *hocon.HoconValue) error {
+		paths = append(paths, path.Render())
+		if path.Equal(hocon.PathOf("akka", "actor")) {
+			return SkipChildren
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"akka",
+		"akka.loglevel",
+		"akka.actor",
+		"akka.loggers",
+		"akka.loggers.0",
+		"akka.loggers.1",
+		"akka.loggers.1.name",
+		"version",
+	}, paths)
+
+	stop := errors.New("stop")
+	count := 0
+	err = conf.Walk(func(path hocon.Path, value *hocon.HoconValue) error {
+		count++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, count)
+}